@@ -56,8 +56,8 @@ func TestClusterInstanceStates(t *testing.T) {
 		running:          make(map[string]*testTask),
 		operationChannel: make(chan operationEvent, 1),
 		factory:          &tests.TestValidationFactory{},
-		arguments: &Arguments{
-			clusters: []string{
+		options: &Options{
+			Clusters: []string{
 				"a_provider",
 				"b_provider",
 			},
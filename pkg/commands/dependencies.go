@@ -0,0 +1,120 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/model"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+// checkDependencies - checks if all prerequisite executions of task are completed. A skip message is returned
+// if any task of prerequisite executions is not passed, quarantined failures are treated as passed. Skipped tasks are
+// not passed as well, so dependent executions are skipped transitively.
+func (ctx *executionContext) checkDependencies(task *testTask) (ready bool, skipMessage string) {
+	dependsOn := task.test.ExecutionConfig.DependsOn
+	if len(dependsOn) == 0 {
+		return true, ""
+	}
+	ctx.RLock()
+	defer ctx.RUnlock()
+	for _, t := range ctx.completed {
+		execName := t.test.ExecutionConfig.Name
+		if utils.Contains(dependsOn, execName) && t.test.Status != model.StatusSuccess && !t.quarantined {
+			return false, fmt.Sprintf("Prerequisite execution %v is not passed: %v on %v is %v",
+				execName, t.test.Name, t.clusterTaskID, statusName(t.test.Status))
+		}
+	}
+	for _, t := range ctx.tasks {
+		execName := t.test.ExecutionConfig.Name
+		if !utils.Contains(dependsOn, execName) {
+			continue
+		}
+		if t.test.Status == model.StatusSkipped {
+			return false, fmt.Sprintf("Prerequisite execution %v is not passed: %v is %v",
+				execName, t.test.Name, statusName(t.test.Status))
+		}
+		return false, ""
+	}
+	for _, t := range ctx.running {
+		if utils.Contains(dependsOn, t.test.ExecutionConfig.Name) {
+			return false, ""
+		}
+	}
+	return true, ""
+}
+
+func (ctx *executionContext) skipTaskDueFailedDependencies(task *testTask, skipMessage string) {
+	logrus.Errorf("Skip %s on %s: %s", task.test.Name, task.clusterTaskID, skipMessage)
+
+	task.test.Status = model.StatusSkipped
+	task.test.SkipMessage = skipMessage
+	for _, cl := range task.clusters {
+		delete(cl.tasks, task.test.Key)
+		cl.completed[task.test.Key] = task
+	}
+	ctx.Lock()
+	ctx.completed = append(ctx.completed, task)
+	ctx.Unlock()
+}
+
+// findDependencyCycle - returns names of executions forming a dependency cycle, or nil if there is no cycles.
+func findDependencyCycle(executions []*config.Execution) []string {
+	dependsOn := map[string][]string{}
+	for _, e := range executions {
+		dependsOn[e.Name] = e.DependsOn
+	}
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := map[string]int{}
+	var path []string
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		switch state[name] {
+		case visiting:
+			for i, n := range path {
+				if n == name {
+					return append(append([]string{}, path[i:]...), name)
+				}
+			}
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range dependsOn[name] {
+			if cycle := visit(dep); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		return nil
+	}
+	for _, e := range executions {
+		if cycle := visit(e.Name); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/execmanager"
+	"github.com/networkservicemesh/cloudtest/pkg/model"
+	"github.com/networkservicemesh/cloudtest/pkg/tests"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+func newDependenciesContext(tmpDir, prerequisiteTest string) *executionContext {
+	testConfig := config.NewCloudTestConfig()
+	testConfig.Timeout = 300
+	testConfig.ConfigRoot = path.Join(tmpDir, "root")
+	testConfig.Statistics.Enabled = false
+	createProvider(testConfig, "a_provider", "echo starting").Instances = 2
+	testConfig.Executions = append(testConfig.Executions, &config.Execution{
+		Name:            "dependent",
+		Timeout:         2,
+		PackageRoot:     "../tests/sample",
+		OnlyRun:         []string{"TestPass"},
+		ClusterSelector: []string{"a_provider"},
+		DependsOn:       []string{"prerequisite"},
+	}, &config.Execution{
+		Name:            "prerequisite",
+		Timeout:         2,
+		PackageRoot:     "../tests/sample",
+		OnlyRun:         []string{prerequisiteTest},
+		ClusterSelector: []string{"a_provider"},
+	})
+	return newExecutionContext(testConfig, &tests.TestValidationFactory{}, &Options{}, execmanager.NewExecutionManager(testConfig.ConfigRoot))
+}
+
+func TestDependentExecutionWaitsForPrerequisite(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), t.Name())
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	ctx := newDependenciesContext(tmpDir, "TestPass")
+	_, err = performTestingContext(ctx)
+	require.NoError(t, err)
+
+	require.Len(t, ctx.completed, 2)
+	require.Equal(t, "prerequisite", ctx.completed[0].test.ExecutionConfig.Name)
+	require.Equal(t, "dependent", ctx.completed[1].test.ExecutionConfig.Name)
+	require.Equal(t, model.StatusSuccess, ctx.completed[1].test.Status)
+}
+
+func TestDependentExecutionSkippedOnFailure(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), t.Name())
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	ctx := newDependenciesContext(tmpDir, "TestFail")
+	report, err := performTestingContext(ctx)
+	require.Error(t, err)
+	require.Equal(t, 1, report.Suites[0].Failures)
+
+	for _, execSuite := range report.Suites[0].Suites {
+		if execSuite.Name != "dependent" {
+			continue
+		}
+		testCase := execSuite.Suites[0].TestCases[0]
+		require.Equal(t, "TestPass", testCase.Name)
+		require.NotNil(t, testCase.SkipMessage)
+		require.True(t, strings.HasPrefix(testCase.SkipMessage.Message, "Prerequisite execution prerequisite is not passed: TestFail"))
+		return
+	}
+	require.Fail(t, "dependent execution is not found in report")
+}
+
+func TestDependentExecutionSkippedTransitively(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), t.Name())
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	ctx := newDependenciesContext(tmpDir, "TestFail")
+	ctx.cloudTestConfig.Executions = append(ctx.cloudTestConfig.Executions, &config.Execution{
+		Name:            "transitive",
+		Timeout:         2,
+		PackageRoot:     "../tests/sample",
+		OnlyRun:         []string{"TestPass"},
+		ClusterSelector: []string{"a_provider"},
+		DependsOn:       []string{"dependent"},
+	})
+	_, err = performTestingContext(ctx)
+	require.Error(t, err)
+
+	statuses := map[string]model.Status{}
+	for _, task := range ctx.completed {
+		statuses[task.test.ExecutionConfig.Name] = task.test.Status
+	}
+	require.Equal(t, map[string]model.Status{
+		"prerequisite": model.StatusFailed,
+		"dependent":    model.StatusSkipped,
+		"transitive":   model.StatusSkipped,
+	}, statuses)
+}
+
+func TestDependentExecutionNotBlockedByQuarantinedFailure(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), t.Name())
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	ctx := newDependenciesContext(tmpDir, "TestFail")
+	ctx.cloudTestConfig.Quarantine.Tests = []*config.QuarantineEntry{{Pattern: "^TestFail$", Reason: "known bug"}}
+	_, err = performTestingContext(ctx)
+	require.NoError(t, err)
+
+	require.Len(t, ctx.completed, 2)
+	require.Equal(t, "prerequisite", ctx.completed[0].test.ExecutionConfig.Name)
+	require.Equal(t, model.StatusFailed, ctx.completed[0].test.Status)
+	require.Equal(t, "dependent", ctx.completed[1].test.ExecutionConfig.Name)
+	require.Equal(t, model.StatusSuccess, ctx.completed[1].test.Status)
+}
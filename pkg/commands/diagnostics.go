@@ -0,0 +1,83 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/cloudtest/pkg/k8s"
+)
+
+const diagnosticsTimeout = 2 * time.Minute
+
+// collectDiagnostics - collects Kubernetes diagnostics of all clusters of failed task into its artifacts directory,
+// returns directories diagnostics are written to.
+func (ctx *executionContext) collectDiagnostics(task *testTask, clusterConfigs []string, out io.Writer) []string {
+	cfg := task.test.ExecutionConfig.Diagnostics
+	if cfg == nil {
+		return nil
+	}
+	factory, ok := ctx.factory.(k8s.DiagnosticsFactory)
+	if !ok {
+		logrus.Warnf("%s: validation factory does not support diagnostics", task.test.Name)
+		return nil
+	}
+	options := &k8s.DiagnosticsOptions{
+		Namespaces:    cfg.Namespaces,
+		LabelSelector: cfg.LabelSelector,
+		LogLines:      cfg.LogLines,
+		MaxFileSize:   cfg.MaxFileSize,
+		MaxSize:       cfg.MaxSize,
+	}
+	if len(options.Namespaces) == 0 && task.namespace != "" {
+		options.Namespaces = []string{task.namespace}
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), diagnosticsTimeout)
+	defer cancel()
+
+	artifactsDir := task.test.ArtifactDirectories[len(task.test.ArtifactDirectories)-1]
+	var dirs []string
+	for i, clusterConfig := range clusterConfigs {
+		dir := path.Join(artifactsDir, "diagnostics", task.clusterInstances[i].id)
+		msg := fmt.Sprintf("%s: collecting diagnostics of cloud %v into %v", task.test.Name, task.clusterInstances[i].id, dir)
+		logrus.Info(msg)
+		_, _ = fmt.Fprintln(out, msg)
+
+		collector, err := factory.CreateDiagnosticsCollector(clusterConfig)
+		if err == nil {
+			err = collector.CollectDiagnostics(timeoutCtx, options, dir)
+		}
+		if err != nil {
+			msg = fmt.Sprintf("%s: failed to collect diagnostics of cloud %v: %v", task.test.Name, task.clusterInstances[i].id, err)
+			logrus.Warn(msg)
+			_, _ = fmt.Fprintln(out, msg)
+		}
+		// Partially collected diagnostics are reported as well.
+		if _, err := os.Stat(dir); err == nil {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
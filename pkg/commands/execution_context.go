@@ -39,11 +39,16 @@ import (
 
 	"github.com/networkservicemesh/cloudtest/pkg/config"
 	"github.com/networkservicemesh/cloudtest/pkg/execmanager"
+	"github.com/networkservicemesh/cloudtest/pkg/external"
+	"github.com/networkservicemesh/cloudtest/pkg/ginkgo"
 	"github.com/networkservicemesh/cloudtest/pkg/k8s"
 	"github.com/networkservicemesh/cloudtest/pkg/model"
 	"github.com/networkservicemesh/cloudtest/pkg/providers"
+	"github.com/networkservicemesh/cloudtest/pkg/providers/kind"
 	"github.com/networkservicemesh/cloudtest/pkg/providers/packet"
+	"github.com/networkservicemesh/cloudtest/pkg/providers/plugin"
 	"github.com/networkservicemesh/cloudtest/pkg/providers/shell"
+	"github.com/networkservicemesh/cloudtest/pkg/providers/static"
 	"github.com/networkservicemesh/cloudtest/pkg/reporting"
 	"github.com/networkservicemesh/cloudtest/pkg/runners"
 	shell_mgr "github.com/networkservicemesh/cloudtest/pkg/shell"
@@ -53,18 +58,10 @@ import (
 
 const (
 	defaultConfigFile string = ".cloudtest.yaml"
-)
 
-// Arguments - command line arguments
-type Arguments struct {
-	clusters        []string // A list of enabled clusters from configuration.
-	kinds           []string // A list of enabled cluster kinds from configuration.
-	tags            []string // Run tests with given tag(s) only
-	providerConfig  string   // A folder to start scaning for tests inside
-	count           int      // Limit number of tests to be run per every cloud
-	instanceOptions providers.InstanceOptions
-	onlyRun         []string // A list of tests to run.
-}
+	operationStart   = "start"
+	operationDestroy = "destroy"
+)
 
 type clusterState uint32
 
@@ -93,12 +90,24 @@ func (s *clusterState) compareAndSwap(oldState, newState clusterState) bool {
 
 // Cluster operation record, to be added as testcase into report.
 type clusterOperationRecord struct {
-	time     time.Time
-	duration time.Duration
-	status   clusterState
-	attempt  int
-	logFile  string
-	errMsg   error
+	operation string // An operation name, start or destroy.
+	time      time.Time
+	duration  time.Duration
+	status    clusterState
+	attempt   int
+	logFile   string
+	errMsg    error
+}
+
+// finish - stores result of destroy operation.
+func (r *clusterOperationRecord) finish(err error) {
+	r.duration = time.Since(r.time)
+	r.errMsg = err
+	if err != nil {
+		r.status.store(clusterCrashed)
+	} else {
+		r.status.store(clusterShutdown)
+	}
 }
 
 type clusterInstance struct {
@@ -108,11 +117,11 @@ type clusterInstance struct {
 	group            *clustersGroup
 	startCount       int
 	id               string
-	taskCancel       context.CancelFunc
 	cancelMonitor    context.CancelFunc
 	startTime        time.Time
 
-	currentTask string
+	currentTasks map[string]*testTask // Tasks running on instance by task id.
+	resetting    bool                 // Instance is reset after a task, it is not shared with other tasks until reset is done.
 
 	executions    []*clusterOperationRecord
 	retestCounter int // If test is requesting retest on this cluster instance, we count how many times it is happening, it will be set to 0 if test is not request retest.
@@ -143,6 +152,11 @@ type testTask struct {
 	clusters         []*clustersGroup
 	clusterInstances []*clusterInstance
 	clusterTaskID    string
+	cancel           context.CancelFunc
+	namespace        string                // A namespace generated for task, if cluster instances are shared between tasks.
+	diagnostics      []string              // Directories with diagnostics collected on failure of current execution.
+	cases            []*reporting.TestCase // Test cases reported into JUnit report for task.
+	quarantined      bool                  // Failure of completed task is quarantined, dependent executions are run.
 }
 
 type eventKind byte
@@ -175,58 +189,53 @@ type executionContext struct {
 	startTime          time.Time
 	clusterReadyTime   time.Time
 	factory            k8s.ValidationFactory
-	arguments          *Arguments
-	clusterWaitGroup   sync.WaitGroup // Wait group for clusters destroying
+	options            *Options
+	runCtx             context.Context // Testing is stopped when it is done.
+	clusterWaitGroup   sync.WaitGroup  // Wait group for clusters destroying
+	rerun              failedTests     // Tests failed in previous run, if only they should be executed.
+	timings            testDurations   // Durations of tests in previous runs.
+	quarantine         quarantine      // Known broken tests, their failures are reported as skipped.
+	failureRules       failureRules    // Rules classifying failures of tests and clusters.
 }
 
 // CloudTestRun - CloudTestRun
 func CloudTestRun(cmd *cloudTestCmd) {
-	var configFileContent []byte
-	var err error
-
-	if cmd.cmdArguments.providerConfig == "" {
-		cmd.cmdArguments.providerConfig = defaultConfigFile
-	}
-
-	configFileContent, err = ioutil.ReadFile(cmd.cmdArguments.providerConfig)
+	testConfig, err := loadConfig(cmd.cmdOptions)
 	if err != nil {
-		logrus.Errorf("Failed to read config file %v", err)
-		return
+		os.Exit(1)
 	}
 
-	// Root config
-	testConfig := config.NewCloudTestConfig()
-	err = parseConfig(testConfig, configFileContent)
+	_, err = PerformTesting(context.Background(), testConfig, k8s.CreateFactory(), WithOptions(cmd.cmdOptions))
 	if err != nil {
-		logrus.Errorf("Failed to parse config %v", err)
+		logrus.Errorf("Failed to process tests %v", err)
 		os.Exit(1)
 	}
+}
 
-	// Process config imports
-	err = performImport(testConfig)
-	if err != nil {
-		logrus.Errorf("Failed to process config imports %v", err)
-		os.Exit(1)
+func loadConfig(options *Options) (*config.CloudTestConfig, error) {
+	if options.ConfigFile == "" {
+		options.ConfigFile = defaultConfigFile
 	}
 
-	_, err = PerformTesting(testConfig, k8s.CreateFactory(), cmd.cmdArguments)
+	// Root config with all imports processed
+	files, err := readConfigFiles(options.ConfigFile)
 	if err != nil {
-		logrus.Errorf("Failed to process tests %v", err)
-		os.Exit(1)
+		logrus.Errorf("Failed to read config %v", err)
+		return nil, err
 	}
+
+	if problems := files.validate(options); len(problems) > 0 {
+		for _, p := range problems {
+			logrus.Errorf("Invalid config: %v", p)
+		}
+		return nil, errors.Errorf("configuration %v has %d problem(s)", options.ConfigFile, len(problems))
+	}
+	return files.config, nil
 }
 
 func performImport(testConfig *config.CloudTestConfig) error {
 	for _, imp := range testConfig.Imports {
-		if utils.FileExists(imp) {
-			if err := importFiles(testConfig, imp); err != nil {
-				return err
-			}
-			continue
-		}
-		dir, pattern := filepath.Split(imp)
-		files := utils.GetAllFiles(dir)
-		imports, err := utils.FilterByPattern(files, pattern)
+		imports, err := resolveImport(imp)
 		if err != nil {
 			return err
 		}
@@ -238,6 +247,16 @@ func performImport(testConfig *config.CloudTestConfig) error {
 	return nil
 }
 
+// resolveImport - returns a list of files matching import, import could be a file name or a folder with file pattern.
+func resolveImport(imp string) ([]string, error) {
+	if utils.FileExists(imp) {
+		return []string{imp}, nil
+	}
+	dir, pattern := filepath.Split(imp)
+	files := utils.GetAllFiles(dir)
+	return utils.FilterByPattern(files, pattern)
+}
+
 func importFiles(testConfig *config.CloudTestConfig, files ...string) error {
 	for _, f := range files {
 		importConfig := &config.CloudTestConfig{}
@@ -259,9 +278,16 @@ func importFiles(testConfig *config.CloudTestConfig, files ...string) error {
 }
 
 // PerformTesting performs testing uses cloud test config. Returns the junit report when testing finished.
-func PerformTesting(config *config.CloudTestConfig, factory k8s.ValidationFactory, arguments *Arguments) (*reporting.JUnitFile, error) {
-	if len(arguments.onlyRun) > 0 {
-		config.OnlyRun = arguments.onlyRun
+// Testing is stopped with an error if passed context is canceled.
+func PerformTesting(runCtx context.Context, config *config.CloudTestConfig, factory k8s.ValidationFactory, opts ...Option) (*reporting.JUnitFile, error) {
+	ctx := newExecutionContext(config, factory, newOptions(opts...), execmanager.NewExecutionManager(config.ConfigRoot))
+	ctx.runCtx = runCtx
+	return performTestingContext(ctx)
+}
+
+func newExecutionContext(config *config.CloudTestConfig, factory k8s.ValidationFactory, options *Options, manager execmanager.ExecutionManager) *executionContext {
+	if len(options.OnlyRun) > 0 {
+		config.OnlyRun = options.OnlyRun
 	}
 
 	if len(config.OnlyRun) > 0 {
@@ -274,14 +300,21 @@ func PerformTesting(config *config.CloudTestConfig, factory k8s.ValidationFactor
 		}
 	}
 
-	if len(arguments.tags) > 0 {
-		logrus.Infof("Imposing top-level 'tags' to all executions: %v", arguments.tags)
+	if len(options.Tags) > 0 {
+		logrus.Infof("Imposing top-level 'tags' to all executions: %v", options.Tags)
 		for _, e := range config.Executions {
-			e.Source.Tags = arguments.tags
+			e.Source.Tags = options.Tags
 		}
 	}
 
-	ctx := &executionContext{
+	if options.ShardIndex > 0 {
+		config.Sharding.Index = options.ShardIndex
+	}
+	if options.ShardTotal > 0 {
+		config.Sharding.Total = options.ShardTotal
+	}
+
+	return &executionContext{
 		cloudTestConfig:    config,
 		operationChannel:   make(chan operationEvent, 100),
 		terminationChannel: make(chan error, utils.Max(10, len(config.HealthCheck))),
@@ -290,10 +323,10 @@ func PerformTesting(config *config.CloudTestConfig, factory k8s.ValidationFactor
 		completed:          []*testTask{},
 		tests:              []*model.TestEntry{},
 		factory:            factory,
-		arguments:          arguments,
-		manager:            execmanager.NewExecutionManager(config.ConfigRoot),
+		options:            options,
+		manager:            manager,
+		runCtx:             context.Background(),
 	}
-	return performTestingContext(ctx)
 }
 
 func performTestingContext(ctx *executionContext) (*reporting.JUnitFile, error) {
@@ -306,9 +339,11 @@ func performTestingContext(ctx *executionContext) (*reporting.JUnitFile, error)
 	if err := ctx.createClusters(); err != nil {
 		return nil, err
 	}
-	cleanupCtx, cancel := context.WithCancel(context.Background())
+	cleanupCtx, cancel := context.WithCancel(ctx.runCtx)
 	defer cancel()
 	go ctx.cleanupClusters(cleanupCtx)
+	// Summary reports are generated after all clusters are deleted, so they include destroy operations.
+	defer ctx.generateSummaryReports()
 	// We need to be sure all clusters will be deleted on end of execution.
 	defer ctx.performShutdown()
 	// Fill tasks to be executed..
@@ -319,6 +354,8 @@ func performTestingContext(ctx *executionContext) (*reporting.JUnitFile, error)
 	if err2 != nil {
 		logrus.Errorf("Error during generation of report: %v", err2)
 	}
+	ctx.saveTimings()
+	ctx.checkQuarantine()
 	if err != nil {
 		return result, err
 	}
@@ -338,15 +375,15 @@ func parseConfig(cloudTestConfig *config.CloudTestConfig, configFileContent []by
 
 func (ctx *executionContext) performShutdown() {
 	// We need to stop all clusters we started
-	if !ctx.arguments.instanceOptions.NoStop {
+	if !ctx.options.InstanceOptions.NoStop {
 		for _, clG := range ctx.clusters {
 			group := clG
 			for _, cInst := range group.instances {
 				curInst := cInst
 				ctx.Lock()
-				if curInst.taskCancel != nil {
+				if len(curInst.currentTasks) > 0 {
 					logrus.Infof("Canceling currently running task")
-					curInst.taskCancel()
+					curInst.cancelTasks()
 				}
 				ctx.Unlock()
 				logrus.Infof("Schedule Closing cluster %v %v", group.config.Name, curInst.id)
@@ -369,7 +406,7 @@ func (ctx *executionContext) performExecution() error {
 	ctx.startTime = time.Now()
 	ctx.clusterReadyTime = ctx.startTime
 
-	timeoutCtx, cancelFunc := context.WithTimeout(context.Background(), time.Duration(ctx.cloudTestConfig.Timeout)*time.Second)
+	timeoutCtx, cancelFunc := context.WithTimeout(ctx.runCtx, time.Duration(ctx.cloudTestConfig.Timeout)*time.Second)
 	defer cancelFunc()
 
 	defer func() {
@@ -391,15 +428,16 @@ func (ctx *executionContext) performExecution() error {
 		ctx.assignTasks()
 		ctx.checkClustersUsage()
 
-		if err := ctx.pollEvents(timeoutCtx, termChannel, statTicker.C); err != nil {
-			return err
-		}
+		// Tasks could be skipped during assignment, so check it before waiting for events.
 		ctx.Lock()
 		noTasks := len(ctx.tasks) == 0 && len(ctx.running) == 0
 		ctx.Unlock()
 		if noTasks {
 			break
 		}
+		if err := ctx.pollEvents(timeoutCtx, termChannel, statTicker.C); err != nil {
+			return err
+		}
 	}
 	logrus.Info("Finished test execution")
 	return nil
@@ -418,6 +456,9 @@ func (ctx *executionContext) pollEvents(c context.Context, osCh <-chan os.Signal
 	case <-osCh:
 		return errors.New("termination request is received")
 	case <-c.Done():
+		if err := ctx.runCtx.Err(); err != nil {
+			return errors.Wrap(err, "testing is canceled")
+		}
 		return errors.Errorf("global timeout elapsed: %v seconds", ctx.cloudTestConfig.Timeout)
 	case err := <-ctx.terminationChannel:
 		return err
@@ -441,7 +482,7 @@ func (ctx *executionContext) assignTasks() {
 	var newTasks []*testTask
 
 	ctx.Lock()
-	tasks := ctx.tasks
+	tasks := sortTasksByPriority(ctx.tasks)
 	ctx.Unlock()
 
 	for _, task := range tasks {
@@ -450,6 +491,23 @@ func (ctx *executionContext) assignTasks() {
 			continue
 		}
 
+		ready, skipMessage := ctx.checkDependencies(task)
+		if skipMessage != "" {
+			ctx.skipTaskDueFailedDependencies(task, skipMessage)
+			continue
+		}
+		if !ready {
+			// wait for prerequisite executions to complete
+			newTasks = append(newTasks, task)
+			continue
+		}
+
+		if !ctx.canRunInParallel(task) {
+			// execution already uses all allowed cluster instances
+			newTasks = append(newTasks, task)
+			continue
+		}
+
 		assignedClusters, unavailableClusters := ctx.selectClustersForTask(task)
 		if len(unavailableClusters) > 0 {
 			ctx.skipTaskDueUnavailableClusters(task, unavailableClusters)
@@ -496,9 +554,9 @@ func (ctx *executionContext) performClusterUpdate(event operationEvent) {
 	ctx.Lock()
 	defer ctx.Unlock()
 	logrus.Infof("Cluster instance %s is updated: state: %v", event.clusterInstance.id, fromClusterState(event.clusterInstance))
-	if event.clusterInstance.taskCancel != nil && event.clusterInstance.state.load() == clusterCrashed {
+	if len(event.clusterInstance.currentTasks) > 0 && event.clusterInstance.state.load() == clusterCrashed {
 		// We have task running on cluster
-		event.clusterInstance.taskCancel()
+		event.clusterInstance.cancelTasks()
 	}
 	if event.clusterInstance.state.load() == clusterReady {
 		if ctx.clusterReadyTime == ctx.startTime {
@@ -551,21 +609,29 @@ func (ctx *executionContext) processTaskUpdate(event operationEvent) {
 }
 
 func (ctx *executionContext) completeTask(event operationEvent) {
+	if event.task.test.Status == model.StatusFailed {
+		ctx.classifyFailure(event.task.test)
+	}
+	// Output and reports are parsed once, dependencies of other tasks are checked by stored result.
+	if status := event.task.test.Status; status == model.StatusFailed || status == model.StatusTimeout {
+		event.task.quarantined = ctx.isQuarantinedFailure(event.task.test)
+	}
 	ctx.Lock()
 	delete(ctx.running, event.task.taskID)
+	ctx.updateFlaky(event.task)
 	ctx.completed = append(ctx.completed, event.task)
-	if event.task.test.Status == model.StatusFailed {
+	if event.task.test.Status == model.StatusFailed && !event.task.quarantined {
 		ctx.failedTestsCount++
 	}
 	if ctx.cloudTestConfig.FailedTestsLimit != 0 && ctx.failedTestsCount == ctx.cloudTestConfig.FailedTestsLimit {
 		ctx.terminationChannel <- errors.Errorf("Allowed limit for failed tests is reached: %d", ctx.cloudTestConfig.FailedTestsLimit)
 	}
 	ctx.Unlock()
-	ctx.makeInstancesReady(event.task.clusterInstances)
+	ctx.makeInstancesReady(event.task)
 }
 
 func (ctx *executionContext) rescheduleTask(event operationEvent) {
-	ctx.makeInstancesReady(event.task.clusterInstances)
+	ctx.makeInstancesReady(event.task)
 	ctx.Lock()
 	delete(ctx.running, event.task.taskID)
 	ctx.tasks = append(ctx.tasks, event.task)
@@ -582,13 +648,15 @@ func (ctx *executionContext) sendClustersUpdate(instances []*clusterInstance) {
 	}
 }
 
-func (ctx *executionContext) makeInstancesReady(instances []*clusterInstance) {
+func (ctx *executionContext) makeInstancesReady(task *testTask) {
 	ctx.Lock()
 	defer ctx.Unlock()
-	for _, inst := range instances {
-		inst.state.compareAndSwap(clusterBusy, clusterReady)
-		inst.taskCancel = nil
-		inst.currentTask = ""
+	task.cancel = nil
+	for _, inst := range task.clusterInstances {
+		delete(inst.currentTasks, task.taskID)
+		if len(inst.currentTasks) == 0 {
+			inst.state.compareAndSwap(clusterBusy, clusterReady)
+		}
 	}
 }
 
@@ -606,6 +674,8 @@ func statusName(status model.Status) interface{} {
 		return "timeout"
 	case model.StatusRerunRequest:
 		return "rerun-request"
+	case model.StatusSkippedSinceNoClusters:
+		return "skipped-no-clusters"
 	}
 	return fmt.Sprintf("code: %v", status)
 }
@@ -614,6 +684,7 @@ func (ctx *executionContext) selectClustersForTask(task *testTask) (clustersToUs
 	for _, cluster := range task.clusters {
 		groupAssigned := false
 		groupAvailable := false
+		var shared *clusterInstance
 		ctx.Lock()
 		for _, ci := range cluster.instances {
 			// No task is assigned for cluster.
@@ -630,13 +701,22 @@ func (ctx *executionContext) selectClustersForTask(task *testTask) (clustersToUs
 				clustersToUse = append(clustersToUse, ci)
 				// We need to remove task from list
 				groupAssigned = true
-			case clusterBusy, clusterStarting, clusterStopping:
+			case clusterBusy:
+				groupAvailable = true
+				// Idle instances are preferred, so remember busy instance could be shared with task.
+				if shared == nil && ci.canShare(task) {
+					shared = ci
+				}
+			case clusterStarting, clusterStopping:
 				groupAvailable = true
 			}
 			if groupAssigned {
 				break
 			}
 		}
+		if !groupAssigned && shared != nil {
+			clustersToUse = append(clustersToUse, shared)
+		}
 		ctx.Unlock()
 		if !groupAvailable {
 			unavailableClusters = append(unavailableClusters, cluster)
@@ -673,8 +753,12 @@ func (ctx *executionContext) printStatistics() {
 		ctx.RUnlock()
 	}
 
+	executionsMsg := ctx.executionsStatistics()
+
 	remaining := ""
-	if len(ctx.completed) > 0 {
+	if estimate, ok := ctx.estimateRemaining(); ok {
+		remaining = fmt.Sprintf("%v", estimate.Round(time.Second))
+	} else if len(ctx.completed) > 0 {
 		oneTask := elapsed / time.Duration(len(ctx.completed))
 		remaining = fmt.Sprintf("%v", (time.Duration(len(ctx.tasks)+len(ctx.running)) * oneTask).Round(time.Second))
 	}
@@ -684,9 +768,19 @@ func (ctx *executionContext) printStatistics() {
 	skippedTests := 0
 	timeoutTests := 0
 
+	flakyTests := 0
+
 	failedNames := ""
+	flakyNames := ""
+	var categories []string
+	categoryCounts := map[string]int{}
+	unclassifiedTests := 0
 
 	for _, t := range ctx.completed {
+		if t.test.Flaky {
+			flakyTests++
+			flakyNames += fmt.Sprintf("\n\t\t%s on %s, %s", t.test.Name, t.clusterTaskID, statusName(t.test.Status))
+		}
 		switch t.test.Status {
 		case model.StatusSuccess:
 			successTests++
@@ -697,21 +791,43 @@ func (ctx *executionContext) printStatistics() {
 		case model.StatusFailed:
 			failedTests++
 			failedNames += fmt.Sprintf("\n\t\t%s on %s", t.test.Name, t.clusterTaskID)
+			if t.test.FailureCategory != "" {
+				failedNames += fmt.Sprintf(", %s: %s", t.test.FailureCategory, t.test.FailureMessage)
+				if categoryCounts[t.test.FailureCategory] == 0 {
+					categories = append(categories, t.test.FailureCategory)
+				}
+				categoryCounts[t.test.FailureCategory]++
+			} else {
+				unclassifiedTests++
+			}
 		case model.StatusSkippedSinceNoClusters:
 			skippedTests++
 		}
 	}
 
+	categoriesMsg := ""
+	for _, category := range categories {
+		categoriesMsg += fmt.Sprintf("\n\t\t%s: %d", category, categoryCounts[category])
+	}
+	if len(categoriesMsg) > 0 && unclassifiedTests > 0 {
+		categoriesMsg += fmt.Sprintf("\n\t\tUnclassified: %d", unclassifiedTests)
+	}
+	if len(categoriesMsg) > 0 {
+		categoriesMsg = "\n\tFailures by category:" + categoriesMsg
+	}
+
 	logrus.Infof("Statistics:" +
 		fmt.Sprintf("\n\tElapsed total: %v", elapsed.Round(time.Second)) +
 		fmt.Sprintf("\n\tTests time: %v", elapsedRunning.Round(time.Second)) +
 		fmt.Sprintf("\n\tTasks  Completed: %d", len(ctx.completed)) +
 		fmt.Sprintf("\n\t       Remaining: %d (~%v)\n", len(ctx.running)+len(ctx.tasks), remaining) +
-		fmt.Sprintf("%s%s", running, clustersMsg.String()) +
+		fmt.Sprintf("%s%s%s", running, clustersMsg.String(), executionsMsg) +
 		fmt.Sprintf("\n\tStatus  Passed: %d"+
 			"\n\tStatus  Failed: %d%v"+
 			"\n\tStatus  Timeout: %d"+
-			"\n\tStatus  Skipped: %d", successTests, failedTests, failedNames, timeoutTests, skippedTests))
+			"\n\tStatus  Skipped: %d"+
+			"\n\tStatus  Flaky: %d%v%v", successTests, failedTests, failedNames, timeoutTests, skippedTests, flakyTests, flakyNames,
+			categoriesMsg))
 }
 
 func fromClusterState(inst *clusterInstance) string {
@@ -722,7 +838,7 @@ func fromClusterState(inst *clusterInstance) string {
 	case clusterAdded:
 		return "added"
 	case clusterBusy:
-		return fmt.Sprintf("running %s", inst.currentTask)
+		return fmt.Sprintf("running %s", inst.currentTaskNames())
 	case clusterCrashed:
 		return "crashed"
 	case clusterNotAvailable:
@@ -749,42 +865,52 @@ func (ctx *executionContext) createTasks() {
 			taskIndex = ctx.createTask(test, taskIndex, taskOrderIndex)
 		}
 	}
+	ctx.sortTasksByDuration()
 }
 
 func (ctx *executionContext) splitTest(test *model.TestEntry, cluster *clustersGroup) []*model.TestEntry {
 	if test.Suite == nil {
 		return []*model.TestEntry{test}
 	}
+	if parts := ctx.timings.splitSuiteByDuration(test, len(cluster.instances), ctx.cloudTestConfig.MinSuiteSize); parts != nil {
+		var result []*model.TestEntry
+		for i, tests := range parts {
+			result = append(result, newSplitTest(test, i, tests))
+		}
+		return result
+	}
 	var result []*model.TestEntry
 	countPerInstance := len(test.Suite.Tests) / len(cluster.instances)
 	if countPerInstance < ctx.cloudTestConfig.MinSuiteSize {
 		countPerInstance = ctx.cloudTestConfig.MinSuiteSize
 	}
 	for i := 0; i < len(cluster.instances); i++ {
-		splitTest := &model.TestEntry{
-			Kind:            test.Kind,
-			Name:            test.Name,
-			Tags:            test.Tags,
-			Status:          test.Status,
-			ExecutionConfig: test.ExecutionConfig,
-			Executions:      []model.TestEntryExecution{},
-			RunScript:       test.RunScript,
-		}
-		splitTest.Name += fmt.Sprint(i + 1)
-		splitTest.Suite = &model.Suite{
-			Name: test.Suite.Name,
-		}
 		if len(test.Suite.Tests)-(i+1)*countPerInstance < countPerInstance || i+1 == len(cluster.instances) {
-			splitTest.Suite.Tests = test.Suite.Tests[i*countPerInstance:]
-			result = append(result, splitTest)
+			result = append(result, newSplitTest(test, i, test.Suite.Tests[i*countPerInstance:]))
 			return result
 		}
-		result = append(result, splitTest)
-		splitTest.Suite.Tests = test.Suite.Tests[i*countPerInstance : (i+1)*countPerInstance]
+		result = append(result, newSplitTest(test, i, test.Suite.Tests[i*countPerInstance:(i+1)*countPerInstance]))
 	}
 	return result
 }
 
+func newSplitTest(test *model.TestEntry, index int, tests []string) *model.TestEntry {
+	return &model.TestEntry{
+		Kind:            test.Kind,
+		Name:            test.Name + fmt.Sprint(index+1),
+		Tags:            test.Tags,
+		Status:          test.Status,
+		ExecutionConfig: test.ExecutionConfig,
+		Executions:      []model.TestEntryExecution{},
+		RunScript:       test.RunScript,
+		Binary:          test.Binary,
+		Suite: &model.Suite{
+			Name:  test.Suite.Name,
+			Tests: tests,
+		},
+	}
+}
+
 func (ctx *executionContext) createTask(entry *model.TestEntry, taskIndex, taskOrderIndex int) int {
 	selector := entry.ExecutionConfig.ClusterSelector
 	// In case of one cluster, we create task copies and execute on every cloud.
@@ -801,6 +927,9 @@ func (ctx *executionContext) createTask(entry *model.TestEntry, taskIndex, taskO
 		}
 	}
 	if entry.ExecutionConfig.ClusterCount > 1 {
+		if !ctx.isFailedOn(entry, selector) {
+			return taskIndex
+		}
 		var tasks []*testTask
 		for _, clusterName := range selector {
 			for _, cluster := range ctx.clusters {
@@ -824,8 +953,8 @@ func (ctx *executionContext) createTask(entry *model.TestEntry, taskIndex, taskO
 		}
 	} else {
 		for _, cluster := range ctx.clusters {
-			if len(selector) > 0 && utils.Contains(selector, cluster.config.Name) ||
-				len(selector) == 0 {
+			if (len(selector) > 0 && utils.Contains(selector, cluster.config.Name) ||
+				len(selector) == 0) && ctx.isFailedOn(entry, []string{cluster.config.Name}) {
 				for _, test := range ctx.splitTest(entry, cluster) {
 					task := ctx.createSingleTask(taskIndex, test, cluster, taskOrderIndex)
 					updateTaskStatus(task)
@@ -850,6 +979,7 @@ func (ctx *executionContext) createSingleTask(taskIndex int, test *model.TestEnt
 			ExecutionConfig: test.ExecutionConfig,
 			Executions:      []model.TestEntryExecution{},
 			RunScript:       test.RunScript,
+			Binary:          test.Binary,
 		},
 		clusters: []*clustersGroup{cluster},
 	}
@@ -866,8 +996,8 @@ func (ctx *executionContext) createSingleTask(taskIndex int, test *model.TestEnt
 
 	// To track cluster task executions.
 	cluster.tasks[task.test.Key] = task
-	if ctx.arguments.count > 0 && taskOrderIndex >= ctx.arguments.count {
-		logrus.Infof("Limit of tests for execution:: %v is reached. Skipping test %s", ctx.arguments.count, test.Name)
+	if ctx.options.Count > 0 && taskOrderIndex >= ctx.options.Count {
+		logrus.Infof("Limit of tests for execution:: %v is reached. Skipping test %s", ctx.options.Count, test.Name)
 		test.Status = model.StatusSkipped
 		ctx.skipped = append(ctx.skipped, task)
 	} else {
@@ -897,7 +1027,7 @@ func (ctx *executionContext) startTask(task *testTask, instances []*clusterInsta
 	for _, ci := range instances {
 		ctx.Lock()
 		ci.state = clusterBusy
-		ci.currentTask = task.test.Name
+		ci.addTask(task)
 		ctx.Unlock()
 	}
 
@@ -926,11 +1056,25 @@ func (ctx *executionContext) startTask(task *testTask, instances []*clusterInsta
 	var runner runners.TestRunner
 	switch task.test.Kind {
 	case model.ShellTestKind:
-		runner = runners.NewShellTestRunner(task.clusterTaskID, task.test)
+		if task.test.ExecutionConfig.Container != nil {
+			runner = runners.NewContainerRunner(task.clusterTaskID, task.test)
+		} else {
+			runner = runners.NewShellTestRunner(task.clusterTaskID, task.test)
+		}
 	case model.GoTestKind:
 		runner = runners.NewGoTestRunner(task.clusterTaskID, task.test, timeout)
 	case model.SuiteTestKind:
 		runner = runners.NewSuiteRunner(task.clusterTaskID, task.test, timeout)
+	case model.GinkgoTestKind:
+		runner = runners.NewGinkgoRunner(task.clusterTaskID, task.test, timeout)
+	case model.ExternalTestKind:
+		runner = runners.NewExternalRunner(task.clusterTaskID, task.test)
+	case model.RegisteredTestKind:
+		registered, ok := runners.Lookup(task.test.ExecutionConfig.Kind)
+		if !ok {
+			return errors.Errorf("no runner registered for execution kind %v", task.test.ExecutionConfig.Kind)
+		}
+		runner = registered.NewRunner(task.clusterTaskID, task.test, timeout)
 	default:
 		return errors.New("invalid task runner")
 	}
@@ -958,6 +1102,9 @@ func prepareEnv(task *testTask, clusterConfigs ...string) []string {
 	}
 	dir := task.test.ArtifactDirectories[len(task.test.ArtifactDirectories)-1]
 	env = append(env, fmt.Sprintf("ARTIFACTS_DIR=%v", dir))
+	if task.namespace != "" {
+		env = append(env, fmt.Sprintf("%s=%v", NamespaceEnv, task.namespace))
+	}
 	return env
 }
 
@@ -989,9 +1136,17 @@ func (ctx *executionContext) executeTask(task *testTask, clusterConfigs []string
 	}
 
 	st := time.Now()
+	writer := bufio.NewWriter(file)
+	if err := ctx.createNamespace(task, clusterConfigs); err != nil {
+		logrus.Errorf("%s: %v", task.test.Name, err)
+		_, _ = writer.WriteString(err.Error())
+		_ = writer.Flush()
+		task.test.Duration = time.Since(st)
+		ctx.updateTestExecution(task, fileName, model.StatusFailed)
+		return
+	}
 	env := prepareEnv(task, clusterConfigs...)
 
-	writer := bufio.NewWriter(file)
 	msg := fmt.Sprintf("Starting %s on %v\n", task.test.Name, task.clusterTaskID)
 	logrus.Info(msg)
 	_, _ = writer.WriteString(msg)
@@ -1003,9 +1158,7 @@ func (ctx *executionContext) executeTask(task *testTask, clusterConfigs []string
 	defer cancel()
 
 	ctx.Lock()
-	for _, inst := range instances {
-		inst.taskCancel = cancel
-	}
+	task.cancel = cancel
 	ctx.handleBeforeAfterScripts(task, writer, clusterConfigs, instances)
 	task.test.Started = time.Now()
 	ctx.Unlock()
@@ -1033,7 +1186,11 @@ func (ctx *executionContext) executeTask(task *testTask, clusterConfigs []string
 			}
 
 		}
+		task.diagnostics = ctx.collectDiagnostics(task, clusterConfigs, writer)
+		_ = writer.Flush()
 	}
+	ctx.deleteNamespace(task, clusterConfigs)
+	ctx.resetInstances(task, instances)
 
 	// Check if test ask us restart it, and have few executions left
 	if errCode != nil && len(ctx.cloudTestConfig.RetestConfig.Patterns) > 0 && ctx.cloudTestConfig.RetestConfig.RestartCount > 0 {
@@ -1050,11 +1207,11 @@ func (ctx *executionContext) executeTask(task *testTask, clusterConfigs []string
 						cinst.cancelMonitor = nil
 						_ = ctx.destroyCluster(cinst, true, false)
 					}
-					ctx.Lock()
-					cinst.taskCancel = nil
-					ctx.Unlock()
 				}
 
+				ctx.Lock()
+				task.cancel = nil
+				ctx.Unlock()
 				ctx.updateTestExecution(task, fileName, model.StatusRerunRequest)
 			} else {
 				msg := fmt.Sprintf("Test %v retry count %v exceed: err: %v", task.test.Name, ctx.cloudTestConfig.RetestConfig.RestartCount, errCode.Error())
@@ -1090,10 +1247,10 @@ func (ctx *executionContext) executeTask(task *testTask, clusterConfigs []string
 				clusterNotAvailable = true
 				_ = ctx.destroyCluster(inst, true, false)
 			}
-			ctx.Lock()
-			inst.taskCancel = nil
-			ctx.Unlock()
 		}
+		ctx.Lock()
+		task.cancel = nil
+		ctx.Unlock()
 		if clusterNotAvailable {
 			logrus.Errorf("Test is canceled due timeout and cluster error.. Will be re-run")
 			ctx.updateTestExecution(task, fileName, model.StatusTimeout)
@@ -1108,6 +1265,35 @@ func (ctx *executionContext) executeTask(task *testTask, clusterConfigs []string
 	}
 }
 
+// resetInstances - resets cluster instances supporting it after task, an instance still used by other tasks is reset
+// by the last of them. If reset is failed, instance is restarted.
+func (ctx *executionContext) resetInstances(task *testTask, instances []*clusterInstance) {
+	for _, inst := range instances {
+		resettable, ok := inst.instance.(providers.ResettableInstance)
+		if !ok {
+			continue
+		}
+		// Finished task is removed from instance, so the last of tasks finished at once sees no other tasks and resets
+		// instance. Instance is not shared with new tasks while it is reset, so all is done under one lock.
+		ctx.Lock()
+		delete(inst.currentTasks, task.taskID)
+		shared := len(inst.currentTasks) > 0
+		inst.resetting = !shared
+		ctx.Unlock()
+		if shared {
+			continue
+		}
+		err := resettable.Reset(ctx.getClusterTimeout(inst.group))
+		ctx.Lock()
+		inst.resetting = false
+		ctx.Unlock()
+		if err != nil {
+			logrus.Errorf("Failed to reset cluster %v after %v: %v", inst.id, task.test.Name, err)
+			_ = ctx.destroyCluster(inst, true, false)
+		}
+	}
+}
+
 func (ctx *executionContext) handleBeforeAfterScripts(task *testTask, writer *bufio.Writer, clusterConfigs []string, instances []*clusterInstance) {
 	for _, inst := range instances {
 		if inst.runningExecution == task.test.ExecutionConfig {
@@ -1158,7 +1344,8 @@ func (ctx *executionContext) matchRestartRequest(fileName string) bool {
 		if err != nil {
 			break
 		}
-		if utils.MatchRetestPattern(ctx.cloudTestConfig.RetestConfig.Patterns, r) {
+		// Output of go tests is stored as JSON events, so patterns are matched with decoded output.
+		if utils.MatchRetestPattern(ctx.cloudTestConfig.RetestConfig.Patterns, suites.OutputLine(r)) {
 			return true
 		}
 	}
@@ -1176,11 +1363,20 @@ func (ctx *executionContext) getTestTimeout(task *testTask) time.Duration {
 
 func (ctx *executionContext) updateTestExecution(task *testTask, fileName string, status model.Status) {
 	task.test.Status = status
-	task.test.Executions = append(task.test.Executions, model.TestEntryExecution{
+	execution := model.TestEntryExecution{
 		Status:     status,
 		Retry:      len(task.test.Executions) + 1,
 		OutputFile: fileName,
-	})
+		Started:    task.test.Started,
+	}
+	execution.Diagnostics, task.diagnostics = task.diagnostics, nil
+	if !task.test.Started.IsZero() {
+		execution.Duration = time.Since(task.test.Started)
+	}
+	for _, inst := range task.clusterInstances {
+		execution.Instances = append(execution.Instances, inst.id)
+	}
+	task.test.Executions = append(task.test.Executions, execution)
 	ctx.operationChannel <- operationEvent{
 		task: task,
 		kind: eventTaskUpdate,
@@ -1202,7 +1398,8 @@ func (ctx *executionContext) startCluster(ci *clusterInstance) bool {
 
 	ci.state.store(clusterStarting)
 	execution := &clusterOperationRecord{
-		time: time.Now(),
+		operation: operationStart,
+		time:      time.Now(),
 	}
 	ci.executions = append(ci.executions, execution)
 	go func() {
@@ -1291,10 +1488,15 @@ func (ctx *executionContext) destroyCluster(ci *clusterInstance, sendUpdate, for
 	}
 	ci.state.store(clusterStopping)
 
+	record := &clusterOperationRecord{
+		operation: operationDestroy,
+		time:      time.Now(),
+	}
 	ctx.Lock()
 	if ci.cancelMonitor != nil {
 		ci.cancelMonitor()
 	}
+	ci.executions = append(ci.executions, record)
 	ctx.Unlock()
 
 	timeout := ctx.getClusterTimeout(ci.group)
@@ -1306,6 +1508,9 @@ func (ctx *executionContext) destroyCluster(ci *clusterInstance, sendUpdate, for
 			if err != nil {
 				logrus.Errorf("Failed to destroy cluster")
 			}
+			ctx.Lock()
+			record.finish(err)
+			ctx.Unlock()
 		}()
 		return nil
 	}
@@ -1313,6 +1518,9 @@ func (ctx *executionContext) destroyCluster(ci *clusterInstance, sendUpdate, for
 	if err != nil {
 		logrus.Errorf("Failed to destroy cluster: %v", err)
 	}
+	ctx.Lock()
+	record.finish(err)
+	ctx.Unlock()
 
 	if ci.group.config.StopDelay != 0 {
 		logrus.Infof("Cluster stop warm-up timeout specified %v", ci.group.config.StopDelay)
@@ -1354,9 +1562,13 @@ func (ctx *executionContext) createClusters() error {
 			testsPerInstance := int(math.Min(float64(ctx.cloudTestConfig.TestsPerClusterInstance), 20))
 			// initial value of cl.Instances is treated as allowed maximum
 			cl.Instances = int(math.Ceil(math.Min(float64(testCount)/float64(testsPerInstance), float64(cl.Instances))))
+			// there is no need in more instances than required to complete all tests during the longest test
+			if total, longest := ctx.estimateClusterTime(cl); longest > 0 {
+				cl.Instances = utils.Max(1, int(math.Min(math.Ceil(float64(total)/float64(longest)), float64(cl.Instances))))
+			}
 			logrus.Infof("Creating %d instances of '%s' cluster to run %d test(s)", cl.Instances, cl.Name, testCount)
 			for i := 0; i < cl.Instances; i++ {
-				cluster, err := provider.CreateCluster(cl, ctx.factory, ctx.manager, ctx.arguments.instanceOptions)
+				cluster, err := provider.CreateCluster(cl, ctx.factory, ctx.manager, ctx.options.InstanceOptions)
 				if err != nil {
 					msg := fmt.Sprintf("Failed to create cluster instance. Error %v", err)
 					logrus.Errorf(msg)
@@ -1390,7 +1602,7 @@ func (ctx *executionContext) createClusters() error {
 func (ctx *executionContext) cleanupClusters(cleanupCtx context.Context) {
 	for _, cl := range ctx.clusters {
 		if cl.config.Enabled {
-			cl.provider.CleanupClusters(cleanupCtx, cl.config, ctx.manager, ctx.arguments.instanceOptions)
+			cl.provider.CleanupClusters(cleanupCtx, cl.config, ctx.manager, ctx.options.InstanceOptions)
 		}
 	}
 }
@@ -1407,17 +1619,17 @@ func (ctx *executionContext) appendTests(tests ...*model.TestEntry) {
 }
 
 func (ctx *executionContext) shouldEnableCluster(cl *config.ClusterProviderConfig) (bool, int) {
-	enabledByCommandLine := utils.Contains(ctx.arguments.clusters, cl.Name)
+	enabledByCommandLine := utils.Contains(ctx.options.Clusters, cl.Name)
 	if !cl.Enabled && !enabledByCommandLine {
 		logrus.Infof("Skipping disabled cluster config: %v", cl.Name)
 		return false, 0
 	}
-	cl.Enabled = len(ctx.arguments.clusters) == 0 || enabledByCommandLine
+	cl.Enabled = len(ctx.options.Clusters) == 0 || enabledByCommandLine
 	if !cl.Enabled {
 		logrus.Infof("Disabling cluster config by cluster filter: %v", cl.Name)
 		return false, 0
 	}
-	cl.Enabled = len(ctx.arguments.kinds) == 0 || utils.Contains(ctx.arguments.kinds, cl.Kind)
+	cl.Enabled = len(ctx.options.Kinds) == 0 || utils.Contains(ctx.options.Kinds, cl.Kind)
 	if !cl.Enabled {
 		logrus.Infof("Disabling cluster config by kind filter: %v", cl.Name)
 		return false, 0
@@ -1427,9 +1639,9 @@ func (ctx *executionContext) shouldEnableCluster(cl *config.ClusterProviderConfi
 	cl.Enabled = false
 	testCount := 0
 	for _, ex := range ctx.cloudTestConfig.Executions {
-		// accept empty Kind to make unit tests work
-		kindMatches := ex.Kind == "" || ex.Kind == cl.Kind
+		kindMatches := executionMatchesKind(ex, cl)
 		mightBeUsed := len(ex.ClusterSelector) == 0 || utils.Contains(ex.ClusterSelector, cl.Name)
+		mightBeUsed = mightBeUsed && ctx.hasFailuresOn(ex, cl.Name)
 		if kindMatches && mightBeUsed && ex.TestsFound > 0 {
 			cl.Enabled = true
 			testCount = testCount + ex.TestsFound
@@ -1445,6 +1657,25 @@ func (ctx *executionContext) shouldEnableCluster(cl *config.ClusterProviderConfi
 func (ctx *executionContext) findTests() error {
 	logrus.Infof("Finding tests")
 
+	if err := ctx.loadTimings(); err != nil {
+		return err
+	}
+	if err := ctx.loadQuarantine(); err != nil {
+		return err
+	}
+	rules, err := newFailureRules(ctx.cloudTestConfig.FailureRules)
+	if err != nil {
+		return err
+	}
+	ctx.failureRules = rules
+	if ctx.options.RerunFailed != "" {
+		failed, err := readFailedTests(ctx.options.RerunFailed)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read report %v", ctx.options.RerunFailed)
+		}
+		ctx.rerun = failed
+	}
+
 	for _, exec := range ctx.cloudTestConfig.Executions {
 		testCount := len(ctx.tests)
 		if exec.Name == "" {
@@ -1459,11 +1690,35 @@ func (ctx *executionContext) findTests() error {
 		} else if exec.Kind == "shell" {
 			tests := ctx.findShellTest(exec)
 			ctx.appendTests(tests...)
+		} else if exec.Kind == "external" {
+			tests := ctx.findShellTest(exec)
+			tests[0].Kind = model.ExternalTestKind
+			ctx.appendTests(tests...)
+		} else if exec.Kind == "ginkgo" {
+			tests, err := ctx.findGinkgoTest(exec)
+			if err != nil {
+				return err
+			}
+			ctx.appendTests(tests...)
+		} else if runner, ok := runners.Lookup(exec.Kind); ok {
+			tests, err := ctx.findRegisteredTest(exec, runner)
+			if err != nil {
+				return err
+			}
+			ctx.appendTests(tests...)
 		} else {
 			return errors.Errorf("unknown executon kind %v", exec.Kind)
 		}
 		exec.TestsFound = len(ctx.tests) - testCount
 	}
+	if ctx.rerun != nil {
+		ctx.filterFailedTests()
+	}
+	if ctx.cloudTestConfig.Sharding.Total > 1 {
+		if err := ctx.selectShardTests(); err != nil {
+			return err
+		}
+	}
 	// If we have execution without tags, we need to remove all tests from it from tagged executions.
 	logrus.Infof("Total tests found: %v", len(ctx.tests))
 	if len(ctx.tests) == 0 {
@@ -1485,12 +1740,77 @@ func (ctx *executionContext) findShellTest(exec *config.Execution) []*model.Test
 	}
 }
 
+// findGinkgoTest - finds specs of Ginkgo suite, they are split between cluster instances like tests of go suites.
+func (ctx *executionContext) findGinkgoTest(exec *config.Execution) ([]*model.TestEntry, error) {
+	st := time.Now()
+	suite, err := ginkgo.Find(ctx.manager, exec)
+	if err != nil {
+		logrus.Errorf("Failed during ginkgo specs lookup %v", err)
+		return nil, err
+	}
+	if len(exec.OnlyRun) > 0 && !utils.Contains(exec.OnlyRun, suite.Name) {
+		var specs []string
+		for _, spec := range suite.Tests {
+			if utils.Contains(exec.OnlyRun, spec) {
+				specs = append(specs, spec)
+			}
+		}
+		suite.Tests = specs
+	}
+	logrus.Infof("Ginkgo specs found: %v Elapsed: %v", len(suite.Tests), time.Since(st))
+	if len(suite.Tests) == 0 {
+		return nil, nil
+	}
+	return []*model.TestEntry{
+		{
+			Name:            suite.Name,
+			Tags:            strings.Join(exec.Source.Tags, ","),
+			Kind:            model.GinkgoTestKind,
+			Suite:           suite,
+			ExecutionConfig: exec,
+		},
+	}, nil
+}
+
+// findRegisteredTest - finds tests of execution executed by registered runner.
+func (ctx *executionContext) findRegisteredTest(exec *config.Execution, runner runners.Runner) ([]*model.TestEntry, error) {
+	if runner.FindTests == nil {
+		return []*model.TestEntry{
+			{
+				Name:            exec.Name,
+				Kind:            model.RegisteredTestKind,
+				ExecutionConfig: exec,
+				Status:          model.StatusAdded,
+				RunScript:       exec.Run,
+			},
+		}, nil
+	}
+	tests, err := runner.FindTests(exec, ctx.manager)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find tests of execution %v", exec.Name)
+	}
+	var result []*model.TestEntry
+	for _, t := range tests {
+		if len(exec.OnlyRun) > 0 && !utils.Contains(exec.OnlyRun, t.Name) {
+			continue
+		}
+		t.Kind = model.RegisteredTestKind
+		t.ExecutionConfig = exec
+		result = append(result, t)
+	}
+	return result, nil
+}
+
 func (ctx *executionContext) findGoTest(executionConfig *config.Execution) ([]*model.TestEntry, error) {
 	st := time.Now()
 
 	logrus.Infof("Starting finding tests by source %v", executionConfig.Source)
 
-	execTests, err := model.GetTestConfiguration(ctx.manager, executionConfig.PackageRoot, executionConfig.Source)
+	var execTests map[string]*model.TestEntry
+	binary, err := ctx.findTestBinary(executionConfig)
+	if err == nil {
+		execTests, err = model.GetCompiledTestConfiguration(ctx.manager, binary, executionConfig.PackageRoot, executionConfig.Source)
+	}
 	if err != nil {
 		logrus.Errorf("Failed during test lookup %v", err)
 		return nil, err
@@ -1508,10 +1828,14 @@ func (ctx *executionContext) findGoTest(executionConfig *config.Execution) ([]*m
 
 	logrus.Infof("Tests found: %v Elapsed: %v", testCount, time.Since(st))
 
+	for _, t := range result {
+		t.Binary = binary
+	}
 	filteredTestsCount := 0
 	for _, t := range execTests {
 		t.Kind = model.GoTestKind
 		t.ExecutionConfig = executionConfig
+		t.Binary = binary
 		if len(executionConfig.OnlyRun) == 0 || utils.Contains(executionConfig.OnlyRun, t.Name) {
 			result = append(result, t)
 		} else {
@@ -1526,6 +1850,22 @@ func (ctx *executionContext) findGoTest(executionConfig *config.Execution) ([]*m
 	return result, nil
 }
 
+// findTestBinary - returns a test binary configured for execution, or compiles package tests into artifacts root.
+func (ctx *executionContext) findTestBinary(execution *config.Execution) (string, error) {
+	if execution.TestBinary != "" {
+		binary, err := filepath.Abs(execution.TestBinary)
+		if err != nil {
+			return "", err
+		}
+		if !utils.FileExists(binary) {
+			return "", errors.Errorf("test binary %v of execution %v is not found", binary, execution.Name)
+		}
+		return binary, nil
+	}
+	output := filepath.Join(ctx.manager.AddFolder("gotest", "bin"), strings.ReplaceAll(execution.Name, "/", "_")+".test")
+	return model.BuildTestBinary(ctx.manager, execution.PackageRoot, output, execution.Source.Tags...)
+}
+
 func (ctx *executionContext) findGoSuites(execution *config.Execution, allTests map[string]*model.TestEntry) ([]*model.TestEntry, error) {
 	testSuites, err := suites.Find(execution.PackageRoot)
 	if err != nil {
@@ -1561,7 +1901,7 @@ func (ctx *executionContext) generateJUnitReportFile() (*reporting.JUnitFile, er
 	ctx.report = &reporting.JUnitFile{}
 
 	summarySuite := &reporting.Suite{
-		Name: "All tests",
+		Name: reporting.SummarySuiteName,
 	}
 
 	// We need to group all tests by executions.
@@ -1644,7 +1984,7 @@ func (ctx *executionContext) generateJUnitReportFile() (*reporting.JUnitFile, er
 
 func (ctx *executionContext) generateClusterFailuresReportSuite() (time.Duration, int, *reporting.Suite) {
 	clusterFailuresSuite := &reporting.Suite{
-		Name: "Cluster failures",
+		Name: reporting.ClusterFailuresSuiteName,
 	}
 
 	clusterFailures := 0
@@ -1687,12 +2027,23 @@ func (ctx *executionContext) generateReportSuiteByTestTasks(
 	for _, test := range tests {
 		var subTestsCount, subFailuresCount int
 		var subDuration time.Duration
+		first := len(suite.TestCases)
 
 		switch test.test.Kind {
-		case model.GoTestKind, model.ShellTestKind:
+		case model.GoTestKind:
+			subTestsCount, subDuration, subFailuresCount = ctx.generateGoTestReport(test, suite)
+		case model.ShellTestKind, model.RegisteredTestKind:
 			subTestsCount, subDuration, subFailuresCount = ctx.generateTestCaseReport(test, suite)
-		case model.SuiteTestKind:
+		case model.SuiteTestKind, model.GinkgoTestKind:
 			subTestsCount, subDuration, subFailuresCount = ctx.generateTestSuiteReport(test, suite)
+		case model.ExternalTestKind:
+			subTestsCount, subDuration, subFailuresCount = ctx.generateExternalTestReport(test, suite)
+		}
+		if test.test.Kind == model.SuiteTestKind || test.test.Kind == model.GinkgoTestKind {
+			// Test cases of suite are added into a nested suite.
+			test.cases = suite.Suites[len(suite.Suites)-1].TestCases
+		} else {
+			test.cases = suite.TestCases[first:]
 		}
 
 		testsCount += subTestsCount
@@ -1732,8 +2083,13 @@ func (ctx *executionContext) generateClusterFailedReportEntry(instID string, exe
 		Name: fmt.Sprintf("Startup-%v", instID),
 		Time: fmt.Sprintf("%v", exec.duration.Seconds()),
 	}
+	failureType := "ERROR"
+	if rule := ctx.failureRules.match(failureTargetCluster, exec.logFile); rule != nil {
+		failureType = rule.Category
+		message = fmt.Sprintf("%v: %v", rule.description(), instID)
+	}
 	startCase.Failure = &reporting.Failure{
-		Type:     "ERROR",
+		Type:     failureType,
 		Contents: result,
 		Message:  message,
 	}
@@ -1755,8 +2111,12 @@ func (ctx *executionContext) generateTestSuiteReport(
 	case model.StatusSkipped, model.StatusSkippedSinceNoClusters:
 		tests = suites.SkipSuite(test.test)
 	default:
+		splitSuite := suites.SplitSuite
+		if test.test.Kind == model.GinkgoTestKind {
+			splitSuite = ginkgo.SplitSuite
+		}
 		var err error
-		if tests, err = suites.SplitSuite(test.test, ctx.manager, test.clusterTaskID); err != nil {
+		if tests, err = splitSuite(test.test, ctx.manager, test.clusterTaskID); err != nil {
 			logrus.Fatalf("error: %+v", err)
 		}
 	}
@@ -1775,6 +2135,88 @@ func (ctx *executionContext) generateTestSuiteReport(
 	return suite.Tests, test.test.Duration, suite.Failures
 }
 
+// generateGoTestReport - adds test cases of go test and all its subtests into suite.
+func (ctx *executionContext) generateGoTestReport(
+	test *testTask,
+	suite *reporting.Suite,
+) (testsCount int, duration time.Duration, failuresCount int) {
+	tests := []*model.TestEntry{test.test}
+	switch test.test.Status {
+	case model.StatusSkipped, model.StatusSkippedSinceNoClusters:
+	default:
+		if split, err := suites.SplitTest(test.test, ctx.manager, test.clusterTaskID); err != nil {
+			logrus.Warnf("Failed to parse output of %v, test is reported as is: %v", test.test.Name, err)
+		} else {
+			tests = split
+		}
+	}
+
+	for _, testEntry := range tests {
+		if testEntry.Name == test.test.Name {
+			// Copies of test could have different results.
+			testEntry.Flaky = testEntry.Flaky || test.test.Flaky
+		}
+		subTestsCount, _, subFailuresCount := ctx.generateTestCaseReport(&testTask{
+			test:             testEntry,
+			clusters:         test.clusters,
+			clusterInstances: test.clusterInstances,
+			clusterTaskID:    test.clusterTaskID,
+		}, suite)
+		testsCount += subTestsCount
+		failuresCount += subFailuresCount
+	}
+	return testsCount, test.test.Duration, failuresCount
+}
+
+// generateExternalTestReport - adds test cases read from reports of external test framework into suite. Task itself is
+// reported as a test case if there are no reports or it is failed, but reports have no failed test cases.
+func (ctx *executionContext) generateExternalTestReport(
+	test *testTask,
+	suite *reporting.Suite,
+) (testsCount int, duration time.Duration, failuresCount int) {
+	var testCases []*reporting.TestCase
+	switch test.test.Status {
+	case model.StatusSuccess, model.StatusFailed, model.StatusTimeout:
+		if len(test.test.ArtifactDirectories) == 0 {
+			// Task is not started.
+			break
+		}
+		dir := test.test.ArtifactDirectories[len(test.test.ArtifactDirectories)-1]
+		var err error
+		if testCases, err = external.ReadReports(dir, test.test.ExecutionConfig.External); err != nil {
+			logrus.Warnf("Failed to read reports of %v, test is reported as is: %v", test.test.Name, err)
+		}
+	}
+
+	quarantined := 0
+	for _, testCase := range testCases {
+		testCase.Cluster = test.clusterTaskID
+		if testCase.Failure == nil {
+			continue
+		}
+		if rule := ctx.quarantine.match(&model.TestEntry{Name: testCase.Name, ExecutionConfig: test.test.ExecutionConfig}); rule != nil {
+			testCase.Failure = nil
+			testCase.SkipMessage = &reporting.SkipMessage{Message: rule.message()}
+			quarantined++
+			continue
+		}
+		failuresCount++
+	}
+	suite.TestCases = append(suite.TestCases, testCases...)
+	testsCount = len(testCases)
+
+	// Framework exits with error if any test case is failed, so failed task itself is reported only if its failure is
+	// not explained by failed test cases, quarantined ones as well.
+	failed := test.test.Status == model.StatusTimeout ||
+		test.test.Status == model.StatusFailed && quarantined == 0
+	if len(testCases) == 0 || failed && failuresCount == 0 {
+		subTestsCount, _, subFailuresCount := ctx.generateTestCaseReport(test, suite)
+		testsCount += subTestsCount
+		failuresCount += subFailuresCount
+	}
+	return testsCount, test.test.Duration, failuresCount
+}
+
 func (ctx *executionContext) generateTestCaseReport(
 	test *testTask,
 	suite *reporting.Suite,
@@ -1785,25 +2227,55 @@ func (ctx *executionContext) generateTestCaseReport(
 		Cluster: test.clusterTaskID,
 	}
 
+	flaky := test.test.Flaky || isFlaky(test.test.Executions)
+	if flaky {
+		testCase.Properties = append(testCase.Properties, &reporting.Property{Name: reporting.FlakyProperty, Value: "true"})
+		for idx, ex := range test.test.Executions {
+			if ex.Status != model.StatusSuccess {
+				testCase.FlakyFailures = append(testCase.FlakyFailures, &reporting.FlakyFailure{
+					Type:     "ERROR",
+					Contents: readExecutionOutput(idx, ex),
+					Message:  fmt.Sprintf("Test execution %v %v", statusName(ex.Status), test.test.Name),
+				})
+			}
+		}
+	}
+
 	switch test.test.Status {
 	case model.StatusFailed, model.StatusTimeout:
-		message := fmt.Sprintf("Test execution failed %v", test.test.Name)
+		if flaky && ctx.cloudTestConfig.Flaky.Policy == flakyPolicyPass {
+			break
+		}
+		if rule := ctx.quarantine.match(test.test); rule != nil {
+			testCase.SkipMessage = &reporting.SkipMessage{Message: rule.message()}
+			break
+		}
+		ctx.classifyFailure(test.test)
+		failureType, message := failureReport(test.test)
 		result := strings.Builder{}
 		for idx, ex := range test.test.Executions {
-			lines, err := utils.ReadFile(ex.OutputFile)
-			if err != nil {
-				logrus.Errorf("Failed to read stored output %v", ex.OutputFile)
-				lines = []string{"Failed to read stored output:", ex.OutputFile, err.Error()}
-			}
-			result.WriteString(fmt.Sprintf("Execution attempt: %v Output file: %v\n", idx, ex.OutputFile))
-			result.WriteString(strings.Join(lines, "\n"))
+			result.WriteString(readExecutionOutput(idx, ex))
 		}
+		testCase.FlakyFailures = nil
 		testCase.Failure = &reporting.Failure{
-			Type:     "ERROR",
+			Type:     failureType,
 			Contents: result.String(),
 			Message:  message,
 		}
 		failuresCount++
+	case model.StatusSuccess:
+		if !flaky || ctx.cloudTestConfig.Flaky.Policy != flakyPolicyFail {
+			break
+		}
+		if rule := ctx.quarantine.match(test.test); rule != nil {
+			testCase.SkipMessage = &reporting.SkipMessage{Message: rule.message()}
+			break
+		}
+		testCase.Failure = &reporting.Failure{
+			Type:    "FLAKY",
+			Message: fmt.Sprintf("Test is flaky %v", test.test.Name),
+		}
+		failuresCount++
 	case model.StatusSkipped:
 		msg := "By limit of number of tests to run"
 		if test.test.SkipMessage != "" {
@@ -1833,6 +2305,20 @@ func (ctx *executionContext) generateTestCaseReport(
 	return 1, test.test.Duration, failuresCount
 }
 
+// readExecutionOutput - returns output of test execution with a header to be stored in report.
+func readExecutionOutput(idx int, ex model.TestEntryExecution) string {
+	lines, err := utils.ReadFile(ex.OutputFile)
+	if err != nil {
+		logrus.Errorf("Failed to read stored output %v", ex.OutputFile)
+		lines = []string{"Failed to read stored output:", ex.OutputFile, err.Error()}
+	}
+	header := fmt.Sprintf("Execution attempt: %v Output file: %v\n", idx, ex.OutputFile)
+	if len(ex.Diagnostics) > 0 {
+		header += fmt.Sprintf("Diagnostics: %v\n", strings.Join(ex.Diagnostics, ", "))
+	}
+	return header + strings.Join(lines, "\n")
+}
+
 func (ctx *executionContext) hasFailedCluster(task *testTask) bool {
 	for _, cg := range task.clusters {
 		failedInstances := 0
@@ -1907,20 +2393,28 @@ func runScript(ctx context.Context, name, script string, env []string, writer *b
 	return nil
 }
 
-func createClusterProviders(manager execmanager.ExecutionManager) (map[string]providers.ClusterProvider, error) {
-	clusterProviders := map[string]providers.ClusterProvider{}
-
-	clusterProviderFactories := map[string]providers.ClusterProviderFunction{
-		"packet": packet.NewPacketClusterProvider,
-		"shell":  shell.NewShellClusterProvider,
+// executionMatchesKind - checks if tests of execution could be run on clusters of provider.
+func executionMatchesKind(ex *config.Execution, cl *config.ClusterProviderConfig) bool {
+	// Tests of registered runners are not bound to provider kind.
+	if _, ok := runners.Lookup(ex.Kind); ok {
+		return true
 	}
+	// accept empty Kind to make unit tests work, ginkgo suites and external tests are run on any cluster like go tests.
+	return ex.Kind == "" || ex.Kind == "ginkgo" || ex.Kind == "external" || ex.Kind == cl.Kind
+}
 
-	for key, factory := range clusterProviderFactories {
-		if _, ok := clusterProviders[key]; ok {
-			msg := fmt.Sprintf("Re-definition of cluster provider... Exiting")
-			logrus.Errorf(msg)
-			return nil, errors.New(msg)
-		}
+func init() {
+	providers.Register("packet", packet.NewPacketClusterProvider)
+	providers.Register("shell", shell.NewShellClusterProvider)
+	providers.Register("kind", kind.NewKindClusterProvider)
+	providers.Register("static", static.NewStaticClusterProvider)
+	providers.Register("exec", plugin.NewPluginClusterProvider)
+}
+
+// createClusterProviders - creates providers of all kinds registered with providers.Register.
+func createClusterProviders(manager execmanager.ExecutionManager) (map[string]providers.ClusterProvider, error) {
+	clusterProviders := map[string]providers.ClusterProvider{}
+	for key, factory := range providers.Registered() {
 		root, err := manager.GetRoot(key)
 		if err != nil {
 			logrus.Errorf("Failed to create cluster provider %v", err)
@@ -1934,22 +2428,22 @@ func createClusterProviders(manager execmanager.ExecutionManager) (map[string]pr
 type cloudTestCmd struct {
 	cobra.Command
 
-	cmdArguments *Arguments
+	cmdOptions *Options
 }
 
 // ExecuteCloudTest - main entry point for command
 func ExecuteCloudTest() {
 	var rootCmd = &cloudTestCmd{
-		cmdArguments: &Arguments{
-			providerConfig: defaultConfigFile,
-			clusters:       []string{},
+		cmdOptions: &Options{
+			ConfigFile: defaultConfigFile,
+			Clusters:   []string{},
 		},
 	}
 	rootCmd.Use = "cloudtest"
 	rootCmd.Short = "NSM Cloud Test is cloud helper continuous integration testing tool"
 	rootCmd.Long = `Allow to execute all set of individual tests across all clouds provided.`
 	rootCmd.Run = func(cmd *cobra.Command, args []string) {
-		rootCmd.cmdArguments.onlyRun = args
+		rootCmd.cmdOptions.OnlyRun = args
 		CloudTestRun(rootCmd)
 	}
 	rootCmd.Args = func(cmd *cobra.Command, args []string) error {
@@ -1966,24 +2460,30 @@ func ExecuteCloudTest() {
 
 func initCmd(rootCmd *cloudTestCmd) {
 	cobra.OnInitialize(initConfig)
-	rootCmd.Flags().StringVarP(&rootCmd.cmdArguments.providerConfig,
+	rootCmd.PersistentFlags().StringVarP(&rootCmd.cmdOptions.ConfigFile,
 		"config", "", "", "Config file, default="+defaultConfigFile)
-	rootCmd.Flags().StringSliceVarP(&rootCmd.cmdArguments.clusters,
+	rootCmd.PersistentFlags().StringSliceVarP(&rootCmd.cmdOptions.Clusters,
 		"cluster", "c", []string{}, "Enable only specified cluster config(s)")
-	rootCmd.Flags().StringSliceVarP(&rootCmd.cmdArguments.kinds,
+	rootCmd.PersistentFlags().StringSliceVarP(&rootCmd.cmdOptions.Kinds,
 		"kind", "k", []string{}, "Enable only specified cluster kind(s)")
-	rootCmd.Flags().StringSliceVarP(&rootCmd.cmdArguments.tags,
+	rootCmd.PersistentFlags().StringSliceVarP(&rootCmd.cmdOptions.Tags,
 		"tags", "t", []string{}, "Run tests with given tag(s) only")
-	rootCmd.Flags().IntVarP(&rootCmd.cmdArguments.count,
+	rootCmd.PersistentFlags().IntVarP(&rootCmd.cmdOptions.Count,
 		"count", "", -1, "Execute only count of tests")
-
-	rootCmd.Flags().BoolVarP(&rootCmd.cmdArguments.instanceOptions.NoStop,
+	rootCmd.PersistentFlags().StringVarP(&rootCmd.cmdOptions.RerunFailed,
+		"rerun-failed", "", "", "Execute only tests failed in passed JUnit report")
+	rootCmd.PersistentFlags().IntVarP(&rootCmd.cmdOptions.ShardIndex,
+		"shard-index", "", 0, "Execute only tests of specified shard, from 1 to shard-total")
+	rootCmd.PersistentFlags().IntVarP(&rootCmd.cmdOptions.ShardTotal,
+		"shard-total", "", 0, "A number of shards tests are split to")
+
+	rootCmd.PersistentFlags().BoolVarP(&rootCmd.cmdOptions.InstanceOptions.NoStop,
 		"noStop", "", false, "Skip stop operations")
-	rootCmd.Flags().BoolVarP(&rootCmd.cmdArguments.instanceOptions.NoInstall,
+	rootCmd.PersistentFlags().BoolVarP(&rootCmd.cmdOptions.InstanceOptions.NoInstall,
 		"noInstall", "", false, "Skip install operations")
-	rootCmd.Flags().BoolVarP(&rootCmd.cmdArguments.instanceOptions.NoPrepare,
+	rootCmd.PersistentFlags().BoolVarP(&rootCmd.cmdOptions.InstanceOptions.NoPrepare,
 		"noPrepare", "", false, "Skip prepare operations")
-	rootCmd.Flags().BoolVarP(&rootCmd.cmdArguments.instanceOptions.NoMaskParameters,
+	rootCmd.PersistentFlags().BoolVarP(&rootCmd.cmdOptions.InstanceOptions.NoMaskParameters,
 		"noMask", "", false, "Disable masking of environment variables in output")
 
 	var versionCmd = &cobra.Command{
@@ -1995,6 +2495,50 @@ func initCmd(rootCmd *cloudTestCmd) {
 		},
 	}
 	rootCmd.AddCommand(versionCmd)
+
+	var planOutput string
+	var planCmd = &cobra.Command{
+		Use:   "plan",
+		Short: "Print a schedule of tasks without starting of clusters",
+		Long:  `Find tests, create cluster instance handles and tasks and print them, no cluster will be started.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			rootCmd.cmdOptions.OnlyRun = args
+			CloudTestPlan(rootCmd, planOutput)
+		},
+	}
+	planCmd.Flags().StringVarP(&planOutput,
+		"output", "o", planOutputText, "Output format, text or json")
+	rootCmd.AddCommand(planCmd)
+
+	var validateCmd = &cobra.Command{
+		Use:   "validate",
+		Short: "Validate configuration file",
+		Long:  `Check configuration file and all imports for unknown fields, invalid references and provider options.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			CloudTestValidate(rootCmd)
+		},
+	}
+	rootCmd.AddCommand(validateCmd)
+
+	var reportCmd = &cobra.Command{
+		Use:   "report",
+		Short: "Operations with JUnit reports",
+	}
+	var mergeOutput string
+	var mergeCmd = &cobra.Command{
+		Use:   "merge report.xml...",
+		Short: "Merge JUnit reports of several runs into one",
+		Long: `Merge JUnit reports by executions and cluster suites, retried test cases are de-duplicated and
+test cases from later reports take precedence.`,
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			CloudTestReportMerge(mergeOutput, args)
+		},
+	}
+	mergeCmd.Flags().StringVarP(&mergeOutput,
+		"output", "o", "junit.xml", "Output file for merged report")
+	reportCmd.AddCommand(mergeCmd)
+	rootCmd.AddCommand(reportCmd)
 }
 
 func initConfig() {
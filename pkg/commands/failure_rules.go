@@ -0,0 +1,122 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/model"
+	"github.com/networkservicemesh/cloudtest/pkg/suites"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+// Failure rule targets.
+const (
+	failureTargetTest    = "test"
+	failureTargetCluster = "cluster"
+)
+
+// failureRule - a parsed failure rule.
+type failureRule struct {
+	*config.FailureRule
+	pattern *regexp.Regexp
+}
+
+// failureRules - rules classifying failures, first matching rule is applied.
+type failureRules []*failureRule
+
+func newFailureRule(rule *config.FailureRule) (*failureRule, error) {
+	if rule.Category == "" {
+		return nil, errors.Errorf("failure rule %v: category should be specified", rule.Pattern)
+	}
+	if rule.Target != "" && rule.Target != failureTargetTest && rule.Target != failureTargetCluster {
+		return nil, errors.Errorf("failure rule %v: unknown target %v, should be %v or %v",
+			rule.Pattern, rule.Target, failureTargetTest, failureTargetCluster)
+	}
+	pattern, err := regexp.Compile(rule.Pattern)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failure rule %v: invalid pattern", rule.Pattern)
+	}
+	return &failureRule{FailureRule: rule, pattern: pattern}, nil
+}
+
+func newFailureRules(rules []*config.FailureRule) (failureRules, error) {
+	var result failureRules
+	for _, rule := range rules {
+		parsed, err := newFailureRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, parsed)
+	}
+	return result, nil
+}
+
+// match - returns a first rule of target matching any line of file, nil if no rule matches or file could not be read.
+// Output of go test JSON events is matched instead of events themselves.
+func (r failureRules) match(target, fileName string) *failureRule {
+	if len(r) == 0 || fileName == "" {
+		return nil
+	}
+	lines, err := utils.ReadFile(fileName)
+	if err != nil {
+		return nil
+	}
+	for _, rule := range r {
+		if rule.Target != "" && rule.Target != target {
+			continue
+		}
+		for _, line := range lines {
+			if rule.pattern.MatchString(suites.OutputLine(line)) {
+				return rule
+			}
+		}
+	}
+	return nil
+}
+
+// description - returns a message of rule, or category if message is not specified.
+func (r *failureRule) description() string {
+	if r.Message != "" {
+		return r.Message
+	}
+	return r.Category
+}
+
+// classifyFailure - assigns a failure category to test by output of its last execution.
+func (ctx *executionContext) classifyFailure(test *model.TestEntry) {
+	test.FailureCategory, test.FailureMessage = "", ""
+	if len(test.Executions) == 0 {
+		return
+	}
+	if rule := ctx.failureRules.match(failureTargetTest, test.Executions[len(test.Executions)-1].OutputFile); rule != nil {
+		test.FailureCategory = rule.Category
+		test.FailureMessage = rule.description()
+	}
+}
+
+// failureReport - returns JUnit failure type and message of test.
+func failureReport(test *model.TestEntry) (failureType, message string) {
+	if test.FailureCategory == "" {
+		return "ERROR", fmt.Sprintf("Test execution failed %v", test.Name)
+	}
+	return test.FailureCategory, fmt.Sprintf("%v: %v", test.FailureMessage, test.Name)
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/model"
+)
+
+func TestFailureRulesMatch(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "cloud-test-failure-rules")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	outputFile := path.Join(dir, "output.log")
+	require.NoError(t, ioutil.WriteFile(outputFile, []byte("=== RUN TestFail\nFailed to pull image nginx\npanic: test\n"), os.ModePerm))
+
+	rules, err := newFailureRules([]*config.FailureRule{
+		{Pattern: "Quota .* exceeded", Category: "QUOTA"},
+		{Pattern: "panic: ", Category: "PANIC", Target: failureTargetCluster},
+		{Pattern: "Failed to pull image", Category: "INFRA", Message: "Image registry is not available"},
+		{Pattern: "panic: ", Category: "TEST_PANIC"},
+	})
+	require.NoError(t, err)
+
+	rule := rules.match(failureTargetTest, outputFile)
+	require.NotNil(t, rule)
+	require.Equal(t, "INFRA", rule.Category)
+	require.Equal(t, "Image registry is not available", rule.description())
+
+	rule = rules.match(failureTargetCluster, outputFile)
+	require.NotNil(t, rule)
+	require.Equal(t, "PANIC", rule.Category)
+	require.Equal(t, "PANIC", rule.description())
+
+	require.Nil(t, rules.match(failureTargetTest, path.Join(dir, "missing.log")))
+	require.Nil(t, failureRules(nil).match(failureTargetTest, outputFile))
+
+	ctx := &executionContext{failureRules: rules}
+	test := &model.TestEntry{
+		Name:       "TestFail",
+		Executions: []model.TestEntryExecution{{OutputFile: outputFile}},
+	}
+	ctx.classifyFailure(test)
+	failureType, message := failureReport(test)
+	require.Equal(t, "INFRA", failureType)
+	require.Equal(t, "Image registry is not available: TestFail", message)
+}
+
+func TestFailureRulesMatchGoTestEvents(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "cloud-test-failure-rules")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	outputFile := path.Join(dir, "output.log")
+	require.NoError(t, ioutil.WriteFile(outputFile, []byte(
+		`{"Action":"run","Test":"TestFail"}`+"\n"+
+			`{"Action":"output","Test":"TestFail","Output":"Get \"registry\": connection refused\n"}`+"\n"+
+			`{"Action":"fail","Test":"TestFail"}`+"\n"), os.ModePerm))
+
+	rules, err := newFailureRules([]*config.FailureRule{
+		{Pattern: `"Action":"run"`, Category: "EVENT"},
+		{Pattern: `^Get "registry": connection refused$`, Category: "INFRA"},
+	})
+	require.NoError(t, err)
+	rule := rules.match(failureTargetTest, outputFile)
+	require.NotNil(t, rule)
+	require.Equal(t, "INFRA", rule.Category)
+
+	ctx := &executionContext{cloudTestConfig: config.NewCloudTestConfig()}
+	ctx.cloudTestConfig.RetestConfig.Patterns = []string{`^Get "registry": connection refused$`}
+	require.True(t, ctx.matchRestartRequest(outputFile))
+	ctx.cloudTestConfig.RetestConfig.Patterns = []string{`"Action":"fail"`}
+	require.False(t, ctx.matchRestartRequest(outputFile))
+}
+
+func TestFailureRulesInvalid(t *testing.T) {
+	_, err := newFailureRule(&config.FailureRule{Pattern: "panic"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failure rule panic: category should be specified")
+
+	_, err = newFailureRule(&config.FailureRule{Pattern: "panic", Category: "PANIC", Target: "node"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failure rule panic: unknown target node, should be test or cluster")
+
+	_, err = newFailureRule(&config.FailureRule{Pattern: "panic(", Category: "PANIC"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failure rule panic(: invalid pattern")
+
+	failureType, message := failureReport(&model.TestEntry{Name: "TestFail"})
+	require.Equal(t, "ERROR", failureType)
+	require.Equal(t, "Test execution failed TestFail", message)
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"github.com/networkservicemesh/cloudtest/pkg/model"
+)
+
+// Results of flaky tests.
+const (
+	flakyPolicyPass = "pass"
+	flakyPolicyFail = "fail"
+)
+
+// isFlaky - checks if test had both failed and passed executions, like a passed retest.
+func isFlaky(executions []model.TestEntryExecution) bool {
+	passed, failed := false, false
+	for i := range executions {
+		switch executions[i].Status {
+		case model.StatusSuccess:
+			passed = true
+		case model.StatusFailed, model.StatusTimeout, model.StatusRerunRequest:
+			failed = true
+		}
+	}
+	return passed && failed
+}
+
+// isSameTest - checks if tasks are copies of same test executed on same clusters, like ones created for test-retry-count.
+func isSameTest(task, other *testTask) bool {
+	return task != other && task.test.Key == other.test.Key &&
+		task.test.ExecutionConfig == other.test.ExecutionConfig &&
+		buildClusterSuiteName(task.clusters) == buildClusterSuiteName(other.clusters)
+}
+
+// isCompleted - checks if task has a final result, timed out task is failed like in isFlaky.
+func isCompleted(task *testTask) bool {
+	switch task.test.Status {
+	case model.StatusSuccess, model.StatusFailed, model.StatusTimeout:
+		return true
+	}
+	return false
+}
+
+// updateFlaky - marks completed task as flaky if it had failed and passed executions, or its copies have different
+// result. Should be called with context locked.
+func (ctx *executionContext) updateFlaky(task *testTask) {
+	if isFlaky(task.test.Executions) {
+		task.test.Flaky = true
+	}
+	if !isCompleted(task) {
+		return
+	}
+	passed := task.test.Status == model.StatusSuccess
+	for _, other := range ctx.completed {
+		if isSameTest(task, other) && isCompleted(other) && (other.test.Status == model.StatusSuccess) != passed {
+			task.test.Flaky = true
+			other.test.Flaky = true
+		}
+	}
+}
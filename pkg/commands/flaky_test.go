@@ -0,0 +1,110 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/model"
+)
+
+func executions(statuses ...model.Status) []model.TestEntryExecution {
+	var result []model.TestEntryExecution
+	for i, status := range statuses {
+		result = append(result, model.TestEntryExecution{Retry: i + 1, Status: status})
+	}
+	return result
+}
+
+func TestIsFlaky(t *testing.T) {
+	require.False(t, isFlaky(executions(model.StatusSuccess)))
+	require.False(t, isFlaky(executions(model.StatusRerunRequest, model.StatusFailed)))
+	require.True(t, isFlaky(executions(model.StatusRerunRequest, model.StatusSuccess)))
+	require.True(t, isFlaky(executions(model.StatusTimeout, model.StatusSuccess)))
+}
+
+func newCopyTask(execution *config.Execution, group *clustersGroup, status model.Status) *testTask {
+	return &testTask{
+		test: &model.TestEntry{
+			Name:            "TestConcurrency",
+			Key:             "a_provider_TestConcurrency",
+			ExecutionConfig: execution,
+			Status:          status,
+			Executions:      executions(status),
+		},
+		clusters: []*clustersGroup{group},
+	}
+}
+
+func TestUpdateFlakyCopies(t *testing.T) {
+	execution := &config.Execution{Name: "simple", ConcurrencyRetry: 3}
+	group := &clustersGroup{config: &config.ClusterProviderConfig{Name: "a_provider"}}
+	newTask := func(status model.Status) *testTask {
+		return newCopyTask(execution, group, status)
+	}
+	other := &testTask{
+		test: &model.TestEntry{
+			Name:            "TestConcurrency",
+			Key:             "a_provider_TestConcurrency",
+			ExecutionConfig: &config.Execution{Name: "other"},
+			Status:          model.StatusFailed,
+		},
+		clusters: []*clustersGroup{group},
+	}
+
+	ctx := &executionContext{}
+	for _, task := range []*testTask{other, newTask(model.StatusSuccess), newTask(model.StatusSuccess)} {
+		ctx.updateFlaky(task)
+		ctx.completed = append(ctx.completed, task)
+	}
+	for _, task := range ctx.completed {
+		require.False(t, task.test.Flaky)
+	}
+
+	failed := newTask(model.StatusFailed)
+	ctx.updateFlaky(failed)
+	require.True(t, failed.test.Flaky)
+	require.False(t, ctx.completed[0].test.Flaky)
+	require.True(t, ctx.completed[1].test.Flaky)
+	require.True(t, ctx.completed[2].test.Flaky)
+}
+
+func TestUpdateFlakyTimedOutCopies(t *testing.T) {
+	execution := &config.Execution{Name: "simple", ConcurrencyRetry: 3}
+	group := &clustersGroup{config: &config.ClusterProviderConfig{Name: "a_provider"}}
+
+	// Timed out and failed copies have same result.
+	ctx := &executionContext{}
+	for _, task := range []*testTask{
+		newCopyTask(execution, group, model.StatusFailed),
+		newCopyTask(execution, group, model.StatusTimeout),
+	} {
+		ctx.updateFlaky(task)
+		ctx.completed = append(ctx.completed, task)
+	}
+	for _, task := range ctx.completed {
+		require.False(t, task.test.Flaky)
+	}
+
+	passed := newCopyTask(execution, group, model.StatusSuccess)
+	ctx.updateFlaky(passed)
+	require.True(t, passed.test.Flaky)
+	require.True(t, ctx.completed[1].test.Flaky)
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/cloudtest/pkg/k8s"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+const (
+	// NamespaceEnv - an environment variable with a namespace generated for test if cluster instances are shared between tests.
+	NamespaceEnv = "TEST_NAMESPACE"
+
+	namespaceTimeout = time.Minute
+)
+
+var invalidNamespaceChars = regexp.MustCompile("[^a-z0-9-]+")
+
+func (ci *clusterInstance) addTask(task *testTask) {
+	if ci.currentTasks == nil {
+		ci.currentTasks = map[string]*testTask{}
+	}
+	ci.currentTasks[task.taskID] = task
+}
+
+func (ci *clusterInstance) cancelTasks() {
+	for _, task := range ci.currentTasks {
+		if task.cancel != nil {
+			task.cancel()
+		}
+	}
+}
+
+func (ci *clusterInstance) currentTaskNames() string {
+	var names []string
+	for _, task := range ci.currentTasks {
+		names = append(names, task.test.Name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// canShare - checks if busy instance could execute one more task. Only tasks of same execution share instance,
+// since before and after scripts are executed per execution, instance being reset is not shared.
+func (ci *clusterInstance) canShare(task *testTask) bool {
+	if ci.resetting || len(ci.currentTasks) == 0 || len(ci.currentTasks) >= ci.group.config.TestsConcurrency {
+		return false
+	}
+	for _, t := range ci.currentTasks {
+		if t.test.ExecutionConfig != task.test.ExecutionConfig {
+			return false
+		}
+	}
+	return true
+}
+
+// isolatedTask - checks if task should be executed in own namespace, since instances of its clusters could be shared.
+func isolatedTask(task *testTask) bool {
+	for _, cl := range task.clusters {
+		if cl.config.TestsConcurrency > 1 {
+			return true
+		}
+	}
+	return false
+}
+
+func newNamespaceName(task *testTask) string {
+	prefix := invalidNamespaceChars.ReplaceAllString(strings.ToLower(task.test.ExecutionConfig.Name), "-")
+	if len(prefix) > 40 {
+		prefix = prefix[:40]
+	}
+	prefix = strings.Trim(prefix, "-")
+	if prefix == "" {
+		prefix = "cloudtest"
+	}
+	return fmt.Sprintf("%s-%s", prefix, utils.NewRandomStr(10))
+}
+
+func (ctx *executionContext) namespaceManagers(clusterConfigs []string) ([]k8s.NamespaceManager, error) {
+	factory, ok := ctx.factory.(k8s.NamespaceFactory)
+	if !ok {
+		return nil, errors.New("validation factory does not support namespaces")
+	}
+	var managers []k8s.NamespaceManager
+	for _, cfg := range clusterConfigs {
+		manager, err := factory.CreateNamespaceManager(cfg)
+		if err != nil {
+			return nil, err
+		}
+		managers = append(managers, manager)
+	}
+	return managers, nil
+}
+
+// createNamespace - creates a fresh namespace for every attempt of task on all its clusters.
+func (ctx *executionContext) createNamespace(task *testTask, clusterConfigs []string) error {
+	task.namespace = ""
+	if !isolatedTask(task) {
+		return nil
+	}
+	managers, err := ctx.namespaceManagers(clusterConfigs)
+	if err != nil {
+		return err
+	}
+	name := newNamespaceName(task)
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), namespaceTimeout)
+	defer cancel()
+	for _, manager := range managers {
+		if err := manager.CreateNamespace(timeoutCtx, name); err != nil {
+			return errors.Wrapf(err, "failed to create namespace %v", name)
+		}
+	}
+	task.namespace = name
+	return nil
+}
+
+// deleteNamespace - deletes a namespace of task, if any.
+func (ctx *executionContext) deleteNamespace(task *testTask, clusterConfigs []string) {
+	if task.namespace == "" {
+		return
+	}
+	managers, err := ctx.namespaceManagers(clusterConfigs)
+	if err != nil {
+		logrus.Errorf("Failed to delete namespace %v: %v", task.namespace, err)
+		return
+	}
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), namespaceTimeout)
+	defer cancel()
+	for _, manager := range managers {
+		if err := manager.DeleteNamespace(timeoutCtx, task.namespace); err != nil {
+			logrus.Errorf("Failed to delete namespace %v: %v", task.namespace, err)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/execmanager"
+	"github.com/networkservicemesh/cloudtest/pkg/model"
+	"github.com/networkservicemesh/cloudtest/pkg/providers"
+	"github.com/networkservicemesh/cloudtest/pkg/tests"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+func newConcurrencyContext(tmpDir string, factory *tests.TestValidationFactory, exec *config.Execution) *executionContext {
+	testConfig := config.NewCloudTestConfig()
+	testConfig.Timeout = 300
+	testConfig.ConfigRoot = path.Join(tmpDir, "root")
+	testConfig.Statistics.Enabled = false
+	testConfig.RetestConfig = config.RetestConfig{
+		Patterns:     []string{"#Please_RETEST#"},
+		RestartCount: 2,
+	}
+	createProvider(testConfig, "a_provider", "echo starting").TestsConcurrency = 3
+	exec.Name = "simple"
+	exec.Timeout = 2
+	exec.PackageRoot = "../tests/sample"
+	exec.ClusterSelector = []string{"a_provider"}
+	testConfig.Executions = append(testConfig.Executions, exec)
+	return newExecutionContext(testConfig, factory, &Options{}, execmanager.NewExecutionManager(testConfig.ConfigRoot))
+}
+
+func TestTestsShareClusterInstance(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), t.Name())
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	factory := &tests.TestValidationFactory{}
+	ctx := newConcurrencyContext(tmpDir, factory, &config.Execution{})
+	_, err = performTestingContext(ctx)
+	require.Error(t, err)
+
+	require.Len(t, ctx.completed, 3)
+	var lastStarted, firstCompleted time.Time
+	for _, task := range ctx.completed {
+		require.Equal(t, "a_provider-1", task.clusterTaskID)
+		if task.test.Started.After(lastStarted) {
+			lastStarted = task.test.Started
+		}
+		if completed := task.test.Started.Add(task.test.Duration); firstCompleted.IsZero() || completed.Before(firstCompleted) {
+			firstCompleted = completed
+		}
+	}
+	require.True(t, lastStarted.Before(firstCompleted), "all tests should be running at once")
+
+	require.Len(t, factory.CreatedNamespaces, 3)
+	require.ElementsMatch(t, factory.CreatedNamespaces, factory.DeletedNamespaces)
+	for _, ns := range factory.CreatedNamespaces {
+		require.True(t, strings.HasPrefix(ns, "simple-"), ns)
+	}
+}
+
+func TestNamespaceIsRecreatedOnRetry(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), t.Name())
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	factory := &tests.TestValidationFactory{}
+	ctx := newConcurrencyContext(tmpDir, factory, &config.Execution{
+		Source: config.ExecutionSource{
+			Tags: []string{"request_restart"},
+		},
+		OnlyRun: []string{"TestRequestRestart"},
+	})
+	_, err = performTestingContext(ctx)
+	require.Error(t, err)
+
+	// Test is started once and restarted twice, every attempt gets own namespace.
+	require.Len(t, factory.CreatedNamespaces, 3)
+	unique := map[string]bool{}
+	for _, ns := range factory.CreatedNamespaces {
+		unique[ns] = true
+	}
+	require.Len(t, unique, 3)
+	require.Equal(t, factory.CreatedNamespaces, factory.DeletedNamespaces)
+}
+
+func TestResettingInstanceIsNotShared(t *testing.T) {
+	execution := &config.Execution{Name: "simple"}
+	inst := &clusterInstance{group: &clustersGroup{config: &config.ClusterProviderConfig{TestsConcurrency: 2}}}
+	inst.addTask(&testTask{taskID: "1", test: &model.TestEntry{ExecutionConfig: execution}})
+
+	task := &testTask{taskID: "2", test: &model.TestEntry{ExecutionConfig: execution}}
+	require.True(t, inst.canShare(task))
+	inst.resetting = true
+	require.False(t, inst.canShare(task))
+}
+
+type resetCountingInstance struct {
+	providers.ClusterInstance
+	resets int
+}
+
+func (i *resetCountingInstance) Reset(time.Duration) error {
+	i.resets++
+	return nil
+}
+
+func TestSharedInstanceIsResetByLastTask(t *testing.T) {
+	execution := &config.Execution{Name: "simple"}
+	instance := &resetCountingInstance{}
+	inst := &clusterInstance{
+		instance: instance,
+		group:    &clustersGroup{config: &config.ClusterProviderConfig{TestsConcurrency: 2}},
+	}
+	first := &testTask{taskID: "1", test: &model.TestEntry{Name: "TestA", ExecutionConfig: execution}}
+	second := &testTask{taskID: "2", test: &model.TestEntry{Name: "TestB", ExecutionConfig: execution}}
+	inst.addTask(first)
+	inst.addTask(second)
+
+	ctx := &executionContext{}
+	ctx.resetInstances(first, []*clusterInstance{inst})
+	require.Equal(t, 0, instance.resets)
+	ctx.resetInstances(second, []*clusterInstance{inst})
+	require.Equal(t, 1, instance.resets)
+	require.False(t, inst.resetting)
+}
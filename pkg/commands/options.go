@@ -0,0 +1,110 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"github.com/networkservicemesh/cloudtest/pkg/providers"
+)
+
+// Options - options of testing, command line arguments are parsed into them.
+type Options struct {
+	Clusters        []string // A list of enabled clusters from configuration.
+	Kinds           []string // A list of enabled cluster kinds from configuration.
+	Tags            []string // Run tests with given tag(s) only
+	ConfigFile      string   // A configuration file, used by command line only.
+	Count           int      // Limit number of tests to be run per every cloud
+	InstanceOptions providers.InstanceOptions
+	OnlyRun         []string // A list of tests to run.
+	RerunFailed     string   // A JUnit report of previous run, only failed tests from it will be executed.
+	ShardIndex      int      // A shard to execute, overrides configuration if specified.
+	ShardTotal      int      // A number of shards, overrides configuration if specified.
+}
+
+// Option - modifies options of testing.
+type Option func(*Options)
+
+// WithOptions - replaces all options with passed ones.
+func WithOptions(options *Options) Option {
+	return func(o *Options) {
+		*o = *options
+	}
+}
+
+// WithClusters - enables only passed clusters from configuration.
+func WithClusters(clusters ...string) Option {
+	return func(o *Options) {
+		o.Clusters = append(o.Clusters, clusters...)
+	}
+}
+
+// WithKinds - enables only clusters of passed provider kinds.
+func WithKinds(kinds ...string) Option {
+	return func(o *Options) {
+		o.Kinds = append(o.Kinds, kinds...)
+	}
+}
+
+// WithTags - runs tests with passed tags only.
+func WithTags(tags ...string) Option {
+	return func(o *Options) {
+		o.Tags = append(o.Tags, tags...)
+	}
+}
+
+// WithCount - limits number of tests to be run per every cloud.
+func WithCount(count int) Option {
+	return func(o *Options) {
+		o.Count = count
+	}
+}
+
+// WithInstanceOptions - sets options of cluster instance operations.
+func WithInstanceOptions(instanceOptions providers.InstanceOptions) Option {
+	return func(o *Options) {
+		o.InstanceOptions = instanceOptions
+	}
+}
+
+// WithOnlyRun - runs only passed tests of all executions.
+func WithOnlyRun(tests ...string) Option {
+	return func(o *Options) {
+		o.OnlyRun = append(o.OnlyRun, tests...)
+	}
+}
+
+// WithRerunFailed - runs only tests failed in passed JUnit report of previous run.
+func WithRerunFailed(report string) Option {
+	return func(o *Options) {
+		o.RerunFailed = report
+	}
+}
+
+// WithShard - runs only tests of shard index from total, overrides configuration.
+func WithShard(index, total int) Option {
+	return func(o *Options) {
+		o.ShardIndex = index
+		o.ShardTotal = total
+	}
+}
+
+func newOptions(opts ...Option) *Options {
+	options := &Options{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
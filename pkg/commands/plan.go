@@ -0,0 +1,237 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/execmanager"
+	"github.com/networkservicemesh/cloudtest/pkg/k8s"
+	"github.com/networkservicemesh/cloudtest/pkg/model"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+const (
+	planOutputText = "text"
+	planOutputJSON = "json"
+)
+
+// ExecutionPlan - a schedule of tasks computed without starting of any cluster.
+type ExecutionPlan struct {
+	Clusters []*ClusterPlan `json:"clusters"`
+	Tasks    []*TaskPlan    `json:"tasks"`
+	Skipped  []*TaskPlan    `json:"skipped"`
+}
+
+// ClusterPlan - a cluster group and a number of instances will be created for it.
+type ClusterPlan struct {
+	Name      string `json:"name"`
+	Kind      string `json:"kind"`
+	Instances int    `json:"instances"`
+	Tasks     int    `json:"tasks"`
+}
+
+// TaskPlan - a single task as it will be scheduled.
+type TaskPlan struct {
+	ID              string   `json:"id,omitempty"`
+	Execution       string   `json:"execution"`
+	Name            string   `json:"name"`
+	Kind            string   `json:"kind"`
+	Clusters        []string `json:"clusters,omitempty"`
+	ClusterSelector []string `json:"cluster-selector,omitempty"`
+	Suite           string   `json:"suite,omitempty"`
+	SuiteTests      []string `json:"suite-tests,omitempty"`
+	Timeout         string   `json:"timeout,omitempty"`
+	SkipReason      string   `json:"skip-reason,omitempty"`
+}
+
+// CloudTestPlan - prints a schedule of tasks for configuration passed by command line.
+func CloudTestPlan(cmd *cloudTestCmd, output string) {
+	testConfig, err := loadConfig(cmd.cmdOptions)
+	if err != nil {
+		os.Exit(1)
+	}
+
+	plan, err := PerformPlan(testConfig, k8s.CreateFactory(), WithOptions(cmd.cmdOptions))
+	if err != nil {
+		logrus.Errorf("Failed to build execution plan %v", err)
+		os.Exit(1)
+	}
+
+	if err := plan.Write(os.Stdout, output); err != nil {
+		logrus.Errorf("Failed to print execution plan %v", err)
+		os.Exit(1)
+	}
+}
+
+// PerformPlan performs tests lookup and tasks creation in the same way as PerformTesting does, but without starting
+// of any cluster. All temporary files are stored into a temporary folder and removed after plan is ready.
+func PerformPlan(config *config.CloudTestConfig, factory k8s.ValidationFactory, opts ...Option) (*ExecutionPlan, error) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "cloudtest-plan")
+	if err != nil {
+		return nil, err
+	}
+	defer utils.ClearFolder(tmpDir, false)
+
+	ctx := newExecutionContext(config, factory, newOptions(opts...), execmanager.NewExecutionManager(tmpDir))
+	if err := ctx.findTests(); err != nil {
+		logrus.Errorf("Error finding tests %v", err)
+		return nil, err
+	}
+	if err := ctx.createClusters(); err != nil {
+		return nil, err
+	}
+	ctx.createTasks()
+
+	return ctx.buildPlan(), nil
+}
+
+func (ctx *executionContext) buildPlan() *ExecutionPlan {
+	plan := &ExecutionPlan{}
+
+	for _, cl := range ctx.clusters {
+		plan.Clusters = append(plan.Clusters, &ClusterPlan{
+			Name:      cl.config.Name,
+			Kind:      cl.config.Kind,
+			Instances: len(cl.instances),
+			Tasks:     len(cl.tasks),
+		})
+	}
+
+	for _, task := range ctx.tasks {
+		taskPlan := ctx.newTaskPlan(task)
+		if task.test.Status == model.StatusSkipped {
+			taskPlan.SkipReason = fmt.Sprintf("not all clusters of selector %v are enabled", task.test.ExecutionConfig.ClusterSelector)
+			plan.Skipped = append(plan.Skipped, taskPlan)
+			continue
+		}
+		plan.Tasks = append(plan.Tasks, taskPlan)
+	}
+
+	for _, task := range ctx.skipped {
+		taskPlan := ctx.newTaskPlan(task)
+		taskPlan.SkipReason = fmt.Sprintf("limit of tests for execution is reached: %v", ctx.options.Count)
+		plan.Skipped = append(plan.Skipped, taskPlan)
+	}
+
+	for _, test := range ctx.tests {
+		if ctx.hasClustersFor(test) {
+			continue
+		}
+		plan.Skipped = append(plan.Skipped, &TaskPlan{
+			Execution:       test.ExecutionConfig.Name,
+			Name:            test.Name,
+			Kind:            testKindName(test),
+			ClusterSelector: test.ExecutionConfig.ClusterSelector,
+			SkipReason:      fmt.Sprintf("no enabled clusters match selector %v", test.ExecutionConfig.ClusterSelector),
+		})
+	}
+
+	return plan
+}
+
+func (ctx *executionContext) newTaskPlan(task *testTask) *TaskPlan {
+	taskPlan := &TaskPlan{
+		ID:              task.taskID,
+		Execution:       task.test.ExecutionConfig.Name,
+		Name:            task.test.Name,
+		Kind:            testKindName(task.test),
+		ClusterSelector: task.test.ExecutionConfig.ClusterSelector,
+		Timeout:         ctx.getTestTimeout(task).String(),
+	}
+	for _, cl := range task.clusters {
+		taskPlan.Clusters = append(taskPlan.Clusters, cl.config.Name)
+	}
+	if task.test.Suite != nil {
+		taskPlan.Suite = task.test.Suite.Name
+		taskPlan.SuiteTests = task.test.Suite.Tests
+	}
+	return taskPlan
+}
+
+// hasClustersFor - checks if at least one task will be created for the test, see createTask.
+func (ctx *executionContext) hasClustersFor(test *model.TestEntry) bool {
+	selector := test.ExecutionConfig.ClusterSelector
+	for _, cluster := range ctx.clusters {
+		if len(selector) == 0 || utils.Contains(selector, cluster.config.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func testKindName(test *model.TestEntry) string {
+	switch test.Kind {
+	case model.GoTestKind:
+		return "gotest"
+	case model.ShellTestKind:
+		return "shell"
+	case model.SuiteTestKind:
+		return "suite"
+	case model.GinkgoTestKind:
+		return "ginkgo"
+	case model.ExternalTestKind:
+		return "external"
+	case model.RegisteredTestKind:
+		return test.ExecutionConfig.Kind
+	}
+	return fmt.Sprintf("code: %v", test.Kind)
+}
+
+// Write - writes plan into writer using one of supported formats: text or json.
+func (p *ExecutionPlan) Write(writer io.Writer, format string) error {
+	switch format {
+	case planOutputJSON:
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(p)
+	case planOutputText, "":
+		_, err := io.WriteString(writer, p.String())
+		return err
+	}
+	return errors.Errorf("unknown output format %v", format)
+}
+
+func (p *ExecutionPlan) String() string {
+	builder := strings.Builder{}
+	_, _ = builder.WriteString("Clusters:\n")
+	for _, cl := range p.Clusters {
+		_, _ = builder.WriteString(fmt.Sprintf("\t%s (%s): instances: %d, tasks: %d\n", cl.Name, cl.Kind, cl.Instances, cl.Tasks))
+	}
+	_, _ = builder.WriteString(fmt.Sprintf("Tasks: %d\n", len(p.Tasks)))
+	for _, t := range p.Tasks {
+		_, _ = builder.WriteString(fmt.Sprintf("\t%s: %s/%s (%s) on %s, timeout: %s\n",
+			t.ID, t.Execution, t.Name, t.Kind, strings.Join(t.Clusters, ","), t.Timeout))
+		if t.Suite != "" {
+			_, _ = builder.WriteString(fmt.Sprintf("\t\t%s: %s\n", t.Suite, strings.Join(t.SuiteTests, ", ")))
+		}
+	}
+	_, _ = builder.WriteString(fmt.Sprintf("Skipped: %d\n", len(p.Skipped)))
+	for _, t := range p.Skipped {
+		_, _ = builder.WriteString(fmt.Sprintf("\t%s/%s (%s): %s\n", t.Execution, t.Name, t.Kind, t.SkipReason))
+	}
+	return builder.String()
+}
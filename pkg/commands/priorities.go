@@ -0,0 +1,85 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// sortTasksByPriority - returns a copy of tasks ordered by priority of executions, order of tasks with same priority
+// is kept, so rescheduled tasks get cluster instances before tasks of executions with lower priority.
+func sortTasksByPriority(tasks []*testTask) []*testTask {
+	result := append([]*testTask{}, tasks...)
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].test.ExecutionConfig.Priority > result[j].test.ExecutionConfig.Priority
+	})
+	return result
+}
+
+// canRunInParallel - checks if task could be started without exceeding of max-parallel of its execution.
+func (ctx *executionContext) canRunInParallel(task *testTask) bool {
+	exec := task.test.ExecutionConfig
+	if exec.MaxParallel <= 0 {
+		return true
+	}
+	used := ctx.usedInstances(exec.Name)
+	// A task requiring more instances than allowed is executed alone.
+	return used == 0 || used+len(task.clusters) <= exec.MaxParallel
+}
+
+// usedInstances - returns a number of cluster instances used by running tasks of execution.
+func (ctx *executionContext) usedInstances(execName string) int {
+	ctx.RLock()
+	defer ctx.RUnlock()
+	used := 0
+	for _, t := range ctx.running {
+		if t.test.ExecutionConfig.Name == execName {
+			used += len(t.clusters)
+		}
+	}
+	return used
+}
+
+// executionsStatistics - returns priority and instances usage of executions with priority or max-parallel configured.
+func (ctx *executionContext) executionsStatistics() string {
+	msg := strings.Builder{}
+	for _, exec := range ctx.cloudTestConfig.Executions {
+		if exec.Priority == 0 && exec.MaxParallel <= 0 {
+			continue
+		}
+		maxParallel := "unlimited"
+		if exec.MaxParallel > 0 {
+			maxParallel = fmt.Sprint(exec.MaxParallel)
+		}
+		tasksLeft := 0
+		ctx.RLock()
+		for _, t := range ctx.tasks {
+			if t.test.ExecutionConfig == exec {
+				tasksLeft++
+			}
+		}
+		ctx.RUnlock()
+		_, _ = msg.WriteString(fmt.Sprintf("\t\tExecution: %v Priority: %v Instances: %v of %v Tasks left: %v\n",
+			exec.Name, exec.Priority, ctx.usedInstances(exec.Name), maxParallel, tasksLeft))
+	}
+	if msg.Len() == 0 {
+		return ""
+	}
+	return "\n\tExecutions:\n" + msg.String()
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/execmanager"
+	"github.com/networkservicemesh/cloudtest/pkg/tests"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+func newPrioritiesContext(tmpDir string, instances int, executions ...*config.Execution) *executionContext {
+	testConfig := config.NewCloudTestConfig()
+	testConfig.Timeout = 300
+	testConfig.ConfigRoot = path.Join(tmpDir, "root")
+	testConfig.Statistics.Enabled = false
+	createProvider(testConfig, "a_provider", "echo starting").Instances = instances
+	for _, exec := range executions {
+		exec.Timeout = 2
+		exec.PackageRoot = "../tests/sample"
+		exec.ClusterSelector = []string{"a_provider"}
+		testConfig.Executions = append(testConfig.Executions, exec)
+	}
+	return newExecutionContext(testConfig, &tests.TestValidationFactory{}, &Options{}, execmanager.NewExecutionManager(testConfig.ConfigRoot))
+}
+
+func TestExecutionPriority(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), t.Name())
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	ctx := newPrioritiesContext(tmpDir, 1, &config.Execution{
+		Name:    "regular",
+		OnlyRun: []string{"TestPass"},
+	}, &config.Execution{
+		Name:     "smoke",
+		OnlyRun:  []string{"TestPass"},
+		Priority: 10,
+	})
+	_, err = performTestingContext(ctx)
+	require.NoError(t, err)
+
+	require.Len(t, ctx.completed, 2)
+	require.Equal(t, "smoke", ctx.completed[0].test.ExecutionConfig.Name)
+	require.Equal(t, "regular", ctx.completed[1].test.ExecutionConfig.Name)
+}
+
+func TestExecutionMaxParallel(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), t.Name())
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	ctx := newPrioritiesContext(tmpDir, 3, &config.Execution{
+		Name:        "hungry",
+		MaxParallel: 1,
+	})
+	_, err = performTestingContext(ctx)
+	require.Error(t, err)
+
+	require.Len(t, ctx.completed, 3)
+	for i := 1; i < len(ctx.completed); i++ {
+		previous := ctx.completed[i-1].test
+		require.False(t, ctx.completed[i].test.Started.Before(previous.Started.Add(previous.Duration)),
+			"%v is started before %v is completed", ctx.completed[i].test.Name, previous.Name)
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/external"
+	"github.com/networkservicemesh/cloudtest/pkg/ginkgo"
+	"github.com/networkservicemesh/cloudtest/pkg/model"
+	"github.com/networkservicemesh/cloudtest/pkg/suites"
+)
+
+const quarantineDateFormat = "2006-01-02"
+
+// quarantineRule - a parsed quarantine entry.
+type quarantineRule struct {
+	entry   *config.QuarantineEntry
+	pattern *regexp.Regexp
+	expired bool
+}
+
+// quarantine - rules of known broken tests, failures of matched tests are reported as skipped.
+type quarantine []*quarantineRule
+
+func newQuarantineRule(entry *config.QuarantineEntry, now time.Time) (*quarantineRule, error) {
+	if entry.Pattern == "" {
+		return nil, errors.New("quarantine pattern should be specified")
+	}
+	pattern, err := regexp.Compile(entry.Pattern)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid quarantine pattern %v", entry.Pattern)
+	}
+	rule := &quarantineRule{
+		entry:   entry,
+		pattern: pattern,
+	}
+	if entry.Expires != "" {
+		expires, err := time.Parse(quarantineDateFormat, entry.Expires)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid quarantine expiration date of %v", entry.Pattern)
+		}
+		// Entry is applied till the end of day.
+		rule.expired = now.After(expires.Add(24 * time.Hour))
+	}
+	return rule, nil
+}
+
+// readQuarantineFile - reads a list of quarantine entries from YAML file.
+func readQuarantineFile(fileName string) ([]*config.QuarantineEntry, error) {
+	content, err := ioutil.ReadFile(filepath.Clean(fileName))
+	if err != nil {
+		return nil, err
+	}
+	var entries []*config.QuarantineEntry
+	if err := yaml.UnmarshalStrict(content, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// newQuarantine - parses quarantine entries of configuration and its file.
+func newQuarantine(cfg *config.QuarantineConfig, now time.Time) (quarantine, error) {
+	entries := cfg.Tests
+	if cfg.File != "" {
+		fileEntries, err := readQuarantineFile(cfg.File)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read quarantine file %v", cfg.File)
+		}
+		entries = append(append([]*config.QuarantineEntry{}, entries...), fileEntries...)
+	}
+	var result quarantine
+	for _, entry := range entries {
+		rule, err := newQuarantineRule(entry, now)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, rule)
+	}
+	return result, nil
+}
+
+// match - returns a not expired rule matching the test, or nil if test is not quarantined.
+func (q quarantine) match(test *model.TestEntry) *quarantineRule {
+	for _, rule := range q {
+		if rule.expired || rule.entry.Execution != "" && rule.entry.Execution != test.ExecutionConfig.Name {
+			continue
+		}
+		if rule.pattern.MatchString(test.Name) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// isQuarantinedFailure - checks if test is quarantined or all failed cases of its suite or external reports are
+// quarantined, so the failure is not counted toward the limit of failed tests.
+func (ctx *executionContext) isQuarantinedFailure(test *model.TestEntry) bool {
+	if ctx.quarantine.match(test) != nil {
+		return true
+	}
+	if len(ctx.quarantine) == 0 {
+		return false
+	}
+	var failed []string
+	var err error
+	switch test.Kind {
+	case model.SuiteTestKind:
+		failed, err = suites.FailedTests(test)
+	case model.GinkgoTestKind:
+		failed, err = ginkgo.FailedSpecs(test)
+	case model.ExternalTestKind:
+		failed, err = failedExternalTests(test)
+	}
+	if err != nil || len(failed) == 0 {
+		return false
+	}
+	for _, name := range failed {
+		if ctx.quarantine.match(&model.TestEntry{Name: name, ExecutionConfig: test.ExecutionConfig}) == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func failedExternalTests(test *model.TestEntry) ([]string, error) {
+	if len(test.ArtifactDirectories) == 0 {
+		return nil, nil
+	}
+	dir := test.ArtifactDirectories[len(test.ArtifactDirectories)-1]
+	testCases, err := external.ReadReports(dir, test.ExecutionConfig.External)
+	if err != nil {
+		return nil, err
+	}
+	var failed []string
+	for _, testCase := range testCases {
+		if testCase.Failure != nil {
+			failed = append(failed, testCase.Name)
+		}
+	}
+	return failed, nil
+}
+
+// message - returns a skip message of quarantined test.
+func (r *quarantineRule) message() string {
+	details := []string{fmt.Sprintf("pattern: %v", r.entry.Pattern)}
+	if r.entry.Owner != "" {
+		details = append(details, fmt.Sprintf("owner: %v", r.entry.Owner))
+	}
+	if r.entry.Expires != "" {
+		details = append(details, fmt.Sprintf("expires: %v", r.entry.Expires))
+	}
+	reason := r.entry.Reason
+	if reason == "" {
+		reason = "no reason specified"
+	}
+	return fmt.Sprintf("Quarantined: %v (%v)", reason, strings.Join(details, ", "))
+}
+
+// loadQuarantine - loads quarantine rules configured, if any, and warns about expired ones.
+func (ctx *executionContext) loadQuarantine() error {
+	rules, err := newQuarantine(&ctx.cloudTestConfig.Quarantine, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		if rule.expired {
+			logrus.Warnf("Quarantine of %v has expired on %v, it is not applied anymore. Owner: %v, reason: %v",
+				rule.entry.Pattern, rule.entry.Expires, rule.entry.Owner, rule.entry.Reason)
+		}
+	}
+	ctx.quarantine = rules
+	return nil
+}
+
+// checkQuarantine - warns about quarantined tests passed all their executions, they might be removed from quarantine.
+func (ctx *executionContext) checkQuarantine() {
+	type quarantinedTest struct {
+		rule       *quarantineRule
+		executions int
+		passed     bool
+	}
+	var keys []string
+	tests := map[string]*quarantinedTest{}
+	for _, task := range ctx.completed {
+		rule := ctx.quarantine.match(task.test)
+		if rule == nil {
+			continue
+		}
+		key := fmt.Sprintf("%v on %v", task.test.Name, buildClusterSuiteName(task.clusters))
+		test, ok := tests[key]
+		if !ok {
+			test = &quarantinedTest{rule: rule, passed: true}
+			tests[key] = test
+			keys = append(keys, key)
+		}
+		test.executions += len(task.test.Executions)
+		test.passed = test.passed && task.test.Status == model.StatusSuccess && !task.test.Flaky
+	}
+	for _, key := range keys {
+		if test := tests[key]; test.passed && test.executions > 0 {
+			logrus.Warnf("Quarantined test %v passed all %d execution(s), consider to remove it from quarantine %v",
+				key, test.executions, test.rule.entry.Pattern)
+		}
+	}
+}
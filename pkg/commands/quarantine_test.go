@@ -0,0 +1,61 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/model"
+)
+
+func TestQuarantineMatch(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	q, err := newQuarantine(&config.QuarantineConfig{
+		Tests: []*config.QuarantineEntry{
+			{Pattern: "^TestExpired$", Expires: "2021-05-31"},
+			{Pattern: "^TestToday$", Expires: "2021-06-01"},
+			{Pattern: "^TestInterdomain", Execution: "interdomain"},
+		},
+	}, now)
+	require.NoError(t, err)
+
+	test := func(execution, name string) *model.TestEntry {
+		return &model.TestEntry{Name: name, ExecutionConfig: &config.Execution{Name: execution}}
+	}
+	require.Nil(t, q.match(test("simple", "TestExpired")))
+	require.NotNil(t, q.match(test("simple", "TestToday")))
+	require.Nil(t, q.match(test("simple", "TestInterdomainPass")))
+	require.NotNil(t, q.match(test("interdomain", "TestInterdomainPass")))
+}
+
+func TestQuarantineInvalidEntries(t *testing.T) {
+	_, err := newQuarantineRule(&config.QuarantineEntry{Pattern: "TestFail("}, time.Now())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid quarantine pattern TestFail(")
+
+	_, err = newQuarantineRule(&config.QuarantineEntry{Pattern: "TestFail", Expires: "31.12.2021"}, time.Now())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid quarantine expiration date of TestFail")
+
+	_, err = newQuarantine(&config.QuarantineConfig{File: "missing-quarantine.yaml"}, time.Now())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to read quarantine file missing-quarantine.yaml")
+}
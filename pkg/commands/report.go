@@ -0,0 +1,69 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/cloudtest/pkg/reporting"
+)
+
+// CloudTestReportMerge - merges JUnit reports passed by command line into output file.
+func CloudTestReportMerge(output string, fileNames []string) {
+	report, err := MergeReports(output, fileNames)
+	if err != nil {
+		logrus.Errorf("Failed to merge reports %v", err)
+		os.Exit(1)
+	}
+	summary := report.Suites[0]
+	logrus.Infof("Merged %d report(s) into %v: tests %d, failures %d", len(fileNames), output, summary.Tests, summary.Failures)
+}
+
+// MergeReports - reads JUnit reports of sharded runs, merges them into one report and writes it to output file.
+// Reports are processed in passed order, so reports of later runs take precedence over earlier ones.
+func MergeReports(output string, fileNames []string) (*reporting.JUnitFile, error) {
+	if len(fileNames) == 0 {
+		return nil, errors.New("no reports to merge")
+	}
+	var reports []*reporting.JUnitFile
+	for _, fileName := range fileNames {
+		report, err := reporting.ReadJUnitFile(fileName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read report %v", fileName)
+		}
+		reports = append(reports, report)
+	}
+	report := reporting.MergeJUnitFiles(reports...)
+
+	content, err := xml.MarshalIndent(report, "  ", "    ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal report")
+	}
+	if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+		return nil, errors.Wrapf(err, "failed to create folder %v", filepath.Dir(output))
+	}
+	if err := ioutil.WriteFile(output, content, 0644); err != nil {
+		return nil, errors.Wrapf(err, "failed to write report %v", output)
+	}
+	return report, nil
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/model"
+	"github.com/networkservicemesh/cloudtest/pkg/reporting"
+	"github.com/networkservicemesh/cloudtest/pkg/suites"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+// failedTests - tests failed during previous run: execution name -> cluster group -> test name -> failed suite tests.
+type failedTests map[string]map[string]map[string][]string
+
+// readFailedTests - reads JUnit report generated by generateJUnitReportFile and collects all failed tests.
+func readFailedTests(fileName string) (failedTests, error) {
+	report, err := reporting.ReadJUnitFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	failed := failedTests{}
+	for _, summarySuite := range report.Suites {
+		for _, execSuite := range summarySuite.Suites {
+			for _, clusterSuite := range execSuite.Suites {
+				failed.add(execSuite.Name, clusterSuite)
+			}
+		}
+	}
+	return failed, nil
+}
+
+func (f failedTests) add(execName string, clusterSuite *reporting.Suite) {
+	tests := map[string][]string{}
+	for _, testCase := range clusterSuite.TestCases {
+		if testCase.Failure != nil {
+			tests[testCase.Name] = nil
+		}
+	}
+	for _, suite := range clusterSuite.Suites {
+		var suiteTests, failedSuiteTests []string
+		setupFailed := false
+		for _, testCase := range suite.TestCases {
+			if testCase.Name == suites.SetupSuite {
+				setupFailed = setupFailed || testCase.Failure != nil
+				continue
+			}
+			suiteTests = append(suiteTests, testCase.Name)
+			if testCase.Failure != nil {
+				failedSuiteTests = append(failedSuiteTests, testCase.Name)
+			}
+		}
+		if setupFailed {
+			// Suite setup is failed, so all suite tests should be executed again.
+			failedSuiteTests = suiteTests
+		}
+		if len(failedSuiteTests) > 0 {
+			tests[suite.Name] = append(tests[suite.Name], failedSuiteTests...)
+		}
+	}
+	if len(tests) == 0 {
+		return
+	}
+	if f[execName] == nil {
+		f[execName] = map[string]map[string][]string{}
+	}
+	f[execName][clusterSuite.Name] = tests
+}
+
+// filterFailedTests - keeps only tests failed in previous run, go suites are narrowed to failed suite tests only.
+func (ctx *executionContext) filterFailedTests() {
+	var tests []*model.TestEntry
+	for _, exec := range ctx.cloudTestConfig.Executions {
+		exec.TestsFound = 0
+	}
+	for _, test := range ctx.tests {
+		found := false
+		var suiteTests []string
+		for _, groupTests := range ctx.rerun[test.ExecutionConfig.Name] {
+			if names, ok := groupTests[test.Name]; ok {
+				found = true
+				suiteTests = append(suiteTests, names...)
+			}
+		}
+		if !found {
+			continue
+		}
+		if test.Suite != nil {
+			narrowed := &model.Suite{Name: test.Suite.Name}
+			for _, name := range test.Suite.Tests {
+				if utils.Contains(suiteTests, name) {
+					narrowed.Tests = append(narrowed.Tests, name)
+				}
+			}
+			if len(narrowed.Tests) == 0 {
+				continue
+			}
+			test.Suite = narrowed
+		}
+		test.ExecutionConfig.TestsFound++
+		tests = append(tests, test)
+	}
+	logrus.Infof("Tests to re-run: %v", len(tests))
+	ctx.tests = tests
+}
+
+// isFailedOn - checks if test is failed in previous run on cluster group.
+func (ctx *executionContext) isFailedOn(test *model.TestEntry, clusterNames []string) bool {
+	if ctx.rerun == nil {
+		return true
+	}
+	_, ok := ctx.rerun[test.ExecutionConfig.Name][strings.Join(clusterNames, "-")][test.Name]
+	return ok
+}
+
+// hasFailuresOn - checks if any test of execution is failed in previous run on cluster groups including cluster.
+func (ctx *executionContext) hasFailuresOn(exec *config.Execution, clusterName string) bool {
+	if ctx.rerun == nil {
+		return true
+	}
+	for group := range ctx.rerun[exec.Name] {
+		if group == clusterName || group == strings.Join(exec.ClusterSelector, "-") && utils.Contains(exec.ClusterSelector, clusterName) {
+			return true
+		}
+	}
+	return false
+}
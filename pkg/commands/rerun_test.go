@@ -0,0 +1,135 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/tests"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+const failedReport = `<testsuites>
+  <testsuite tests="6" failures="3" time="10" name="All tests">
+    <testsuite tests="4" failures="2" time="8" name="simple">
+      <testsuite tests="2" failures="1" time="4" name="a_provider">
+        <testcase classname="" name="TestPass" time="1" cluster_instance="a_provider-1"></testcase>
+        <testcase classname="" name="TestFail" time="1" cluster_instance="a_provider-1">
+          <failure message="Test execution failed TestFail" type="ERROR">output</failure>
+        </testcase>
+        <testsuite tests="3" failures="1" time="2" name="TestRunSuite">
+          <testcase classname="" name="TestA" time="1" cluster_instance="a_provider-1"></testcase>
+          <testcase classname="" name="TestB" time="1" cluster_instance="a_provider-1">
+            <failure message="Test execution failed TestB" type="ERROR">output</failure>
+          </testcase>
+        </testsuite>
+      </testsuite>
+      <testsuite tests="2" failures="1" time="4" name="b_provider">
+        <testsuite tests="2" failures="1" time="2" name="TestSetupSuite">
+          <testcase classname="" name="SetupSuite" time="1" cluster_instance="b_provider-1">
+            <failure message="Test execution failed SetupSuite" type="ERROR">output</failure>
+          </testcase>
+          <testcase classname="" name="TestA" time="0" cluster_instance="b_provider-1">
+            <skipped message="Suite setup failed"></skipped>
+          </testcase>
+          <testcase classname="" name="TestB" time="0" cluster_instance="b_provider-1">
+            <skipped message="Suite setup failed"></skipped>
+          </testcase>
+        </testsuite>
+      </testsuite>
+    </testsuite>
+    <testsuite tests="2" failures="0" time="2" name="passed">
+      <testsuite tests="2" failures="0" time="2" name="a_provider">
+        <testcase classname="" name="TestPass" time="1" cluster_instance="a_provider-1"></testcase>
+      </testsuite>
+    </testsuite>
+  </testsuite>
+</testsuites>`
+
+func TestReadFailedTests(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), t.Name())
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	reportFile := path.Join(tmpDir, "junit.xml")
+	require.NoError(t, ioutil.WriteFile(reportFile, []byte(failedReport), os.ModePerm))
+
+	failed, err := readFailedTests(reportFile)
+	require.NoError(t, err)
+	require.Equal(t, failedTests{
+		"simple": {
+			"a_provider": {
+				"TestFail":     nil,
+				"TestRunSuite": {"TestB"},
+			},
+			"b_provider": {
+				"TestSetupSuite": {"TestA", "TestB"},
+			},
+		},
+	}, failed)
+}
+
+func TestRerunFailedTests(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), t.Name())
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	newConfig := func() *config.CloudTestConfig {
+		testConfig := config.NewCloudTestConfig()
+		testConfig.Timeout = 300
+		testConfig.ConfigRoot = path.Join(tmpDir, "root")
+		testConfig.Statistics.Enabled = false
+		testConfig.Reporting.JUnitReportFile = "junit.xml"
+		createProvider(testConfig, "a_provider", "echo starting")
+		createProvider(testConfig, "b_provider", "echo starting")
+		testConfig.Executions = append(testConfig.Executions, &config.Execution{
+			Name:            "simple",
+			Timeout:         2,
+			PackageRoot:     "../tests/sample",
+			ClusterSelector: []string{"a_provider"},
+		})
+		return testConfig
+	}
+
+	report, err := PerformTesting(context.Background(), newConfig(), &tests.TestValidationFactory{})
+	require.Error(t, err)
+	require.Equal(t, 3, report.Suites[0].Tests)
+	require.Equal(t, 2, report.Suites[0].Failures)
+
+	reportFile := path.Join(tmpDir, "junit.xml")
+	require.NoError(t, os.Rename(path.Join(tmpDir, "root", "junit.xml"), reportFile))
+
+	report, err = PerformTesting(context.Background(), newConfig(), &tests.TestValidationFactory{}, WithRerunFailed(reportFile))
+	require.Error(t, err)
+	require.Equal(t, 2, report.Suites[0].Tests)
+	require.Equal(t, 2, report.Suites[0].Failures)
+
+	clusterSuite := report.Suites[0].Suites[0].Suites[0]
+	require.Equal(t, "a_provider", clusterSuite.Name)
+	var names []string
+	for _, testCase := range clusterSuite.TestCases {
+		names = append(names, testCase.Name)
+	}
+	require.ElementsMatch(t, []string{"TestFail", "TestTimeout"}, names)
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/cloudtest/pkg/model"
+)
+
+// selectShardTests - keeps only tests of current shard. Tests are ordered by stable key, so every shard has same
+// view on tests list. If durations of previous run are available, tests are balanced by durations, longest first,
+// otherwise tests are distributed between shards one by one. Timings database is not used, since it differs between
+// machines shards are executed on.
+func (ctx *executionContext) selectShardTests() error {
+	sharding := &ctx.cloudTestConfig.Sharding
+	if sharding.Index < 1 || sharding.Index > sharding.Total {
+		return errors.Errorf("shard index %v should be in range from 1 to %v", sharding.Index, sharding.Total)
+	}
+
+	var durations testDurations
+	if sharding.Durations != "" {
+		var err error
+		if durations, err = readTestDurations(sharding.Durations); err != nil {
+			return errors.Wrapf(err, "failed to read test durations from %v", sharding.Durations)
+		}
+	}
+
+	shards := splitShards(ctx.tests, sharding.Total, durations)
+
+	selected := map[*model.TestEntry]bool{}
+	for _, test := range shards[sharding.Index-1] {
+		selected[test] = true
+	}
+	var tests []*model.TestEntry
+	for _, exec := range ctx.cloudTestConfig.Executions {
+		exec.TestsFound = 0
+	}
+	// Keep an original order of tests, since it could be shuffled.
+	for _, test := range ctx.tests {
+		if selected[test] {
+			test.ExecutionConfig.TestsFound++
+			tests = append(tests, test)
+		}
+	}
+	logrus.Infof("Tests selected for shard %v of %v: %v", sharding.Index, sharding.Total, len(tests))
+	ctx.tests = tests
+	return nil
+}
+
+// splitShards - splits tests deterministically into total shards.
+func splitShards(tests []*model.TestEntry, total int, durations testDurations) [][]*model.TestEntry {
+	type weightedTest struct {
+		test   *model.TestEntry
+		key    string
+		weight time.Duration
+		known  bool
+	}
+	weighted := make([]*weightedTest, 0, len(tests))
+	var knownTotal time.Duration
+	knownCount := 0
+	for _, test := range tests {
+		w := &weightedTest{
+			test: test,
+			key:  timingKey(test.ExecutionConfig.Name, test.Name),
+		}
+		w.weight, w.known = durations.weight(test)
+		if w.known {
+			knownTotal += w.weight
+			knownCount++
+		}
+		weighted = append(weighted, w)
+	}
+
+	shards := make([][]*model.TestEntry, total)
+	if knownCount == 0 {
+		sort.Slice(weighted, func(i, j int) bool { return weighted[i].key < weighted[j].key })
+		for i, w := range weighted {
+			shards[i%total] = append(shards[i%total], w.test)
+		}
+		return shards
+	}
+
+	// Tests without history are treated as average ones.
+	average := knownTotal / time.Duration(knownCount)
+	for _, w := range weighted {
+		if !w.known {
+			w.weight = average
+		}
+	}
+	sort.Slice(weighted, func(i, j int) bool {
+		if weighted[i].weight != weighted[j].weight {
+			return weighted[i].weight > weighted[j].weight
+		}
+		return weighted[i].key < weighted[j].key
+	})
+	loads := make([]time.Duration, total)
+	for _, w := range weighted {
+		shard := 0
+		for i := range loads {
+			if loads[i] < loads[shard] {
+				shard = i
+			}
+		}
+		loads[shard] += w.weight
+		shards[shard] = append(shards[shard], w.test)
+	}
+	return shards
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/model"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+func shardTestNames(shard []*model.TestEntry) []string {
+	var names []string
+	for _, test := range shard {
+		names = append(names, test.Name)
+	}
+	return names
+}
+
+func newShardTests(names ...string) []*model.TestEntry {
+	exec := &config.Execution{Name: "simple"}
+	var tests []*model.TestEntry
+	for _, name := range names {
+		tests = append(tests, &model.TestEntry{Name: name, ExecutionConfig: exec})
+	}
+	return tests
+}
+
+func TestSplitShardsByKey(t *testing.T) {
+	shards := splitShards(newShardTests("TestD", "TestA", "TestC", "TestB", "TestE"), 2, nil)
+	require.Equal(t, []string{"TestA", "TestC", "TestE"}, shardTestNames(shards[0]))
+	require.Equal(t, []string{"TestB", "TestD"}, shardTestNames(shards[1]))
+
+	// Order of discovered tests should not affect shards.
+	shards = splitShards(newShardTests("TestE", "TestD", "TestC", "TestB", "TestA"), 2, nil)
+	require.Equal(t, []string{"TestA", "TestC", "TestE"}, shardTestNames(shards[0]))
+}
+
+func TestSplitShardsByDurations(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), t.Name())
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	reportFile := path.Join(tmpDir, "junit.xml")
+	require.NoError(t, ioutil.WriteFile(reportFile, []byte(`<testsuites>
+  <testsuite name="All tests">
+    <testsuite name="simple">
+      <testsuite name="a_provider">
+        <testcase name="TestA" time="10"></testcase>
+        <testcase name="TestB" time="4"></testcase>
+        <testcase name="TestC" time="3"></testcase>
+        <testsuite name="TestSuite">
+          <testcase name="TestX" time="2"></testcase>
+          <testcase name="TestY" time="5"></testcase>
+        </testsuite>
+      </testsuite>
+      <testsuite name="b_provider">
+        <testcase name="TestC" time="1"></testcase>
+      </testsuite>
+    </testsuite>
+  </testsuite>
+</testsuites>`), os.ModePerm))
+
+	durations, err := readTestDurations(reportFile)
+	require.NoError(t, err)
+	require.Equal(t, 10*time.Second, durations["simple/TestA"])
+	require.Equal(t, 5*time.Second, durations["simple/TestSuite/TestY"])
+	// Durations of same test on different cluster groups are summed.
+	require.Equal(t, 4*time.Second, durations["simple/TestC"])
+
+	tests := newShardTests("TestA", "TestB", "TestC", "TestSuite", "TestNew")
+	tests[3].Suite = &model.Suite{Name: "TestSuite", Tests: []string{"TestX", "TestY"}}
+
+	// TestSuite takes 7s, TestNew has no history and takes average 6.25s.
+	shards := splitShards(tests, 2, durations)
+	require.Equal(t, []string{"TestA", "TestB"}, shardTestNames(shards[0]))
+	require.Equal(t, []string{"TestSuite", "TestNew", "TestC"}, shardTestNames(shards[1]))
+}
+
+func TestSelectShardTestsIgnoresTimings(t *testing.T) {
+	cfg := config.NewCloudTestConfig()
+	cfg.Sharding.Index = 1
+	cfg.Sharding.Total = 2
+	tests := newShardTests("TestA", "TestB", "TestC", "TestD")
+	cfg.Executions = []*config.Execution{tests[0].ExecutionConfig}
+	ctx := &executionContext{
+		cloudTestConfig: cfg,
+		tests:           tests,
+		// Local timings differ between machines, so they should not affect the split.
+		timings: testDurations{"simple/TestA": time.Minute, "simple/TestC": time.Minute},
+	}
+	require.NoError(t, ctx.selectShardTests())
+	require.Equal(t, []string{"TestA", "TestC"}, shardTestNames(ctx.tests))
+}
@@ -0,0 +1,260 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+
+	"github.com/networkservicemesh/cloudtest/pkg/reporting"
+)
+
+func clusterStateName(state clusterState) string {
+	switch state {
+	case clusterAdded:
+		return "added"
+	case clusterReady:
+		return "ready"
+	case clusterBusy:
+		return "busy"
+	case clusterStarting:
+		return "starting"
+	case clusterStopping:
+		return "stopping"
+	case clusterCrashed:
+		return "crashed"
+	case clusterNotAvailable:
+		return "not-available"
+	case clusterShutdown:
+		return "shutdown"
+	}
+	return fmt.Sprintf("code: %v", state)
+}
+
+// operationStatus - returns a status of cluster operation in terms of test statuses.
+func operationStatus(state clusterState) string {
+	switch state {
+	case clusterReady, clusterShutdown:
+		return "success"
+	case clusterCrashed:
+		return "failed"
+	case clusterNotAvailable:
+		return "not-available"
+	}
+	return "in-progress"
+}
+
+// createSummary - collects a summary of testing run used to generate reports.
+func (ctx *executionContext) createSummary() *reporting.Summary {
+	ctx.RLock()
+	defer ctx.RUnlock()
+
+	summary := &reporting.Summary{
+		Started:  ctx.startTime,
+		Duration: time.Since(ctx.startTime),
+	}
+	if ctx.cloudTestConfig.Reporting.JSONConfig {
+		summary.Config = ctx.effectiveConfig()
+	}
+
+	executionsTests := ctx.getAllTestTasksGroupedByExecutions()
+	for _, execution := range ctx.cloudTestConfig.Executions {
+		tasks, ok := executionsTests[execution.Name]
+		if !ok {
+			continue
+		}
+		executionSummary := &reporting.ExecutionSummary{Name: execution.Name}
+		groups := map[string]*reporting.GroupSummary{}
+		for _, task := range tasks {
+			groupName := buildClusterSuiteName(task.clusters)
+			group, ok := groups[groupName]
+			if !ok {
+				group = &reporting.GroupSummary{Name: groupName}
+				groups[groupName] = group
+				executionSummary.Groups = append(executionSummary.Groups, group)
+			}
+			group.Tests = append(group.Tests, ctx.newTestSummary(task))
+		}
+		sort.Slice(executionSummary.Groups, func(i, j int) bool {
+			return executionSummary.Groups[i].Name < executionSummary.Groups[j].Name
+		})
+		for _, group := range executionSummary.Groups {
+			sort.Slice(group.Tests, func(i, j int) bool {
+				return group.Tests[i].Name < group.Tests[j].Name
+			})
+		}
+		summary.Executions = append(summary.Executions, executionSummary)
+	}
+
+	for _, cluster := range ctx.clusters {
+		clusterSummary := &reporting.ClusterSummary{Name: cluster.config.Name}
+		for _, inst := range cluster.instances {
+			instSummary := &reporting.InstanceSummary{
+				ID:    inst.id,
+				State: clusterStateName(inst.state.load()),
+			}
+			for _, record := range inst.executions {
+				operation := &reporting.OperationSummary{
+					Name:     record.operation,
+					Status:   operationStatus(record.status.load()),
+					Attempt:  record.attempt,
+					Started:  record.time,
+					Duration: record.duration,
+					LogFile:  record.logFile,
+				}
+				if record.errMsg != nil {
+					operation.Error = record.errMsg.Error()
+				}
+				if operation.Status == "failed" {
+					if rule := ctx.failureRules.match(failureTargetCluster, record.logFile); rule != nil {
+						operation.FailureCategory = rule.Category
+						operation.FailureMessage = rule.description()
+					}
+				}
+				instSummary.Operations = append(instSummary.Operations, operation)
+			}
+			clusterSummary.Instances = append(clusterSummary.Instances, instSummary)
+		}
+		summary.Clusters = append(summary.Clusters, clusterSummary)
+	}
+	return summary
+}
+
+func (ctx *executionContext) newTestSummary(task *testTask) *reporting.TestSummary {
+	test := &reporting.TestSummary{
+		Name:                task.test.Name,
+		Key:                 task.test.Key,
+		Status:              fmt.Sprint(statusName(task.test.Status)),
+		Flaky:               task.test.Flaky,
+		SkipMessage:         task.test.SkipMessage,
+		FailureCategory:     task.test.FailureCategory,
+		FailureMessage:      task.test.FailureMessage,
+		Started:             task.test.Started,
+		Duration:            task.test.Duration,
+		ArtifactDirectories: task.test.ArtifactDirectories,
+	}
+	for _, execution := range task.test.Executions {
+		test.Executions = append(test.Executions, &reporting.TestExecutionSummary{
+			Retry:       execution.Retry,
+			Status:      fmt.Sprint(statusName(execution.Status)),
+			Instances:   execution.Instances,
+			Started:     execution.Started,
+			Duration:    execution.Duration,
+			OutputFile:  execution.OutputFile,
+			Diagnostics: execution.Diagnostics,
+		})
+	}
+	if rule := ctx.quarantine.match(task.test); rule != nil {
+		test.Quarantine = rule.message()
+	}
+	for _, testCase := range task.cases {
+		// Task itself is reported as a test case, if it is not split or its failure is not explained by test cases.
+		if testCase.Name != task.test.Name {
+			test.Cases = append(test.Cases, newTestCaseSummary(testCase))
+		}
+	}
+	if count := len(test.Executions); count > 0 {
+		test.Cluster = fmt.Sprint(test.Executions[count-1].Instances)
+	}
+	return test
+}
+
+func newTestCaseSummary(testCase *reporting.TestCase) *reporting.TestCaseSummary {
+	result := &reporting.TestCaseSummary{
+		Name:   testCase.Name,
+		Status: "success",
+	}
+	if seconds, err := strconv.ParseFloat(testCase.Time, 64); err == nil {
+		result.Duration = time.Duration(seconds * float64(time.Second))
+	}
+	switch {
+	case testCase.Failure != nil:
+		result.Status = "failed"
+		result.Message = testCase.Failure.Message
+	case testCase.SkipMessage != nil:
+		result.Status = "skipped"
+		result.Message = testCase.SkipMessage.Message
+	}
+	return result
+}
+
+// effectiveConfig - returns configuration with imports and command line options applied, as generic document.
+func (ctx *executionContext) effectiveConfig() interface{} {
+	content, err := yaml.Marshal(ctx.cloudTestConfig)
+	if err != nil {
+		logrus.Errorf("failed to store effective configuration: %v", err)
+		return nil
+	}
+	var result interface{}
+	if err := yaml.Unmarshal(content, &result); err != nil {
+		logrus.Errorf("failed to store effective configuration: %v", err)
+		return nil
+	}
+	return result
+}
+
+// generateSummaryReports - writes summary reports configured in reporting section.
+func (ctx *executionContext) generateSummaryReports() {
+	reportingConfig := &ctx.cloudTestConfig.Reporting
+	if reportingConfig.HTMLReportFile == "" && reportingConfig.JSONReportFile == "" && reportingConfig.MarkdownReportFile == "" {
+		return
+	}
+	summary := ctx.createSummary()
+
+	if reportingConfig.JSONReportFile != "" {
+		ctx.writeReport("JSON", reportingConfig.JSONReportFile, func(w io.Writer, _ string) error {
+			return reporting.WriteJSON(w, summary)
+		})
+	}
+	if reportingConfig.HTMLReportFile != "" {
+		ctx.writeReport("HTML", reportingConfig.HTMLReportFile, func(w io.Writer, baseDir string) error {
+			return reporting.WriteHTML(w, summary, baseDir)
+		})
+	}
+	if reportingConfig.MarkdownReportFile != "" {
+		ctx.writeReport("Markdown", reportingConfig.MarkdownReportFile, func(w io.Writer, _ string) error {
+			return reporting.WriteMarkdown(w, summary, reporting.MarkdownOptions{
+				OutputLines: reportingConfig.MarkdownOutputLines,
+				MaxSize:     reportingConfig.MarkdownMaxSize,
+			})
+		})
+	}
+}
+
+// writeReport - writes a report file, baseDir passed to write is an absolute location of report folder.
+func (ctx *executionContext) writeReport(kind, fileName string, write func(w io.Writer, baseDir string) error) {
+	baseDir, err := filepath.Abs(path.Dir(path.Join(ctx.cloudTestConfig.ConfigRoot, fileName)))
+	if err != nil {
+		logrus.Errorf("failed to generate %v report: %v", kind, err)
+		return
+	}
+	output := &bytes.Buffer{}
+	if err := write(output, baseDir); err != nil {
+		logrus.Errorf("failed to generate %v report: %v", kind, err)
+		return
+	}
+	ctx.manager.AddFile(fileName, output.Bytes())
+}
@@ -0,0 +1,318 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/model"
+	"github.com/networkservicemesh/cloudtest/pkg/reporting"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+const timingsVersion = 1
+
+// testDurations - durations of tests by timing key, execution/test for tests and execution/suite/test for suite tests.
+type testDurations map[string]time.Duration
+
+// timingsFile - a format of timings database file.
+type timingsFile struct {
+	Version   int                `json:"version"`
+	Durations map[string]float64 `json:"durations"` // Durations of tests in seconds.
+}
+
+func timingKey(names ...string) string {
+	return strings.Join(names, "/")
+}
+
+// readTestDurations - reads durations of tests from JUnit report, durations of same test on different cluster groups are summed.
+func readTestDurations(fileName string) (testDurations, error) {
+	report, err := reporting.ReadJUnitFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	durations := testDurations{}
+	forEachTestCase(report, func(testCase *reporting.TestCase, key string, duration time.Duration) {
+		durations[key] += duration
+	})
+	return durations, nil
+}
+
+// reportDurations - collects durations of executed tests from JUnit report, if same test is executed on few cluster
+// groups, the longest duration is used.
+func reportDurations(report *reporting.JUnitFile) testDurations {
+	durations := testDurations{}
+	forEachTestCase(report, func(testCase *reporting.TestCase, key string, duration time.Duration) {
+		if testCase.SkipMessage == nil && duration > durations[key] {
+			durations[key] = duration
+		}
+	})
+	return durations
+}
+
+// forEachTestCase - calls visit for every test case of JUnit report with a valid duration.
+func forEachTestCase(report *reporting.JUnitFile,
+	visit func(testCase *reporting.TestCase, key string, duration time.Duration)) {
+	add := func(testCase *reporting.TestCase, names ...string) {
+		seconds, err := strconv.ParseFloat(testCase.Time, 64)
+		if err != nil {
+			return
+		}
+		visit(testCase, timingKey(names...), time.Duration(seconds*float64(time.Second)))
+	}
+	for _, summarySuite := range report.Suites {
+		for _, execSuite := range summarySuite.Suites {
+			for _, clusterSuite := range execSuite.Suites {
+				for _, testCase := range clusterSuite.TestCases {
+					add(testCase, execSuite.Name, testCase.Name)
+				}
+				for _, suite := range clusterSuite.Suites {
+					for _, testCase := range suite.TestCases {
+						add(testCase, execSuite.Name, suite.Name, testCase.Name)
+					}
+				}
+			}
+		}
+	}
+}
+
+// loadTimings - loads timings database, an empty database is returned if file does not exist yet.
+func loadTimings(fileName string) (testDurations, error) {
+	content, err := ioutil.ReadFile(filepath.Clean(fileName))
+	if os.IsNotExist(err) {
+		return testDurations{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	file := &timingsFile{}
+	if err = json.Unmarshal(content, file); err != nil {
+		return nil, err
+	}
+	if file.Version != timingsVersion {
+		return nil, errors.Errorf("unsupported timings version %v", file.Version)
+	}
+	durations := testDurations{}
+	for key, seconds := range file.Durations {
+		durations[key] = time.Duration(seconds * float64(time.Second))
+	}
+	return durations, nil
+}
+
+// save - stores timings database into file.
+func (d testDurations) save(fileName string) error {
+	file := &timingsFile{
+		Version:   timingsVersion,
+		Durations: map[string]float64{},
+	}
+	for key, duration := range d {
+		file.Durations[key] = duration.Seconds()
+	}
+	content, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fileName, content, 0644)
+}
+
+// weight - returns a known duration of test, suite duration is a sum of durations of its tests.
+func (d testDurations) weight(test *model.TestEntry) (time.Duration, bool) {
+	if test.Suite == nil {
+		duration, ok := d[timingKey(test.ExecutionConfig.Name, test.Name)]
+		return duration, ok
+	}
+	var result time.Duration
+	found := false
+	for _, name := range test.Suite.Tests {
+		if duration, ok := d[timingKey(test.ExecutionConfig.Name, test.Suite.Name, name)]; ok {
+			result += duration
+			found = true
+		}
+	}
+	return result, found
+}
+
+// estimate - returns a known duration of test or an average duration of known tests, zero if nothing is known.
+func (d testDurations) estimate(test *model.TestEntry) time.Duration {
+	if duration, ok := d.weight(test); ok {
+		return duration
+	}
+	return d.average()
+}
+
+func (d testDurations) average() time.Duration {
+	if len(d) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, duration := range d {
+		total += duration
+	}
+	return total / time.Duration(len(d))
+}
+
+// loadTimings - loads timings database configured, if any.
+func (ctx *executionContext) loadTimings() error {
+	fileName := ctx.cloudTestConfig.Timings.File
+	if fileName == "" {
+		return nil
+	}
+	timings, err := loadTimings(fileName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read timings %v", fileName)
+	}
+	logrus.Infof("Loaded durations of %v test(s) from %v", len(timings), fileName)
+	ctx.timings = timings
+	return nil
+}
+
+// saveTimings - updates timings database with durations of tests executed in current run.
+func (ctx *executionContext) saveTimings() {
+	fileName := ctx.cloudTestConfig.Timings.File
+	if fileName == "" || ctx.report == nil {
+		return
+	}
+	timings := ctx.timings
+	if timings == nil {
+		timings = testDurations{}
+	}
+	for key, duration := range reportDurations(ctx.report) {
+		timings[key] = duration
+	}
+	if err := timings.save(fileName); err != nil {
+		logrus.Errorf("Failed to store timings %v: %v", fileName, err)
+	}
+}
+
+// sortTasksByDuration - orders tasks longest first, so long tasks are not started at the end of testing.
+func (ctx *executionContext) sortTasksByDuration() {
+	if len(ctx.timings) == 0 {
+		return
+	}
+	sort.SliceStable(ctx.tasks, func(i, j int) bool {
+		return ctx.timings.estimate(ctx.tasks[i].test) > ctx.timings.estimate(ctx.tasks[j].test)
+	})
+}
+
+// estimateClusterTime - returns a total duration of tests could be executed on cluster and the longest duration of
+// test could not be split, suites are split by tests so the longest suite test is used for them.
+func (ctx *executionContext) estimateClusterTime(cl *config.ClusterProviderConfig) (total, longest time.Duration) {
+	if len(ctx.timings) == 0 {
+		return 0, 0
+	}
+	for _, test := range ctx.tests {
+		ex := test.ExecutionConfig
+		kindMatches := executionMatchesKind(ex, cl)
+		mightBeUsed := len(ex.ClusterSelector) == 0 || utils.Contains(ex.ClusterSelector, cl.Name)
+		if !kindMatches || !mightBeUsed {
+			continue
+		}
+		duration := ctx.timings.estimate(test)
+		total += duration
+		if test.Suite != nil {
+			duration = 0
+			for _, name := range test.Suite.Tests {
+				if d := ctx.timings[timingKey(ex.Name, test.Suite.Name, name)]; d > duration {
+					duration = d
+				}
+			}
+		}
+		if duration > longest {
+			longest = duration
+		}
+	}
+	return total, longest
+}
+
+// splitSuiteByDuration - splits suite tests into parts of close estimated durations, keeping at least minSize tests in
+// every part. Returns nil if durations of suite tests are not known.
+func (d testDurations) splitSuiteByDuration(test *model.TestEntry, parts, minSize int) [][]string {
+	if _, ok := d.weight(test); !ok || parts < 2 {
+		return nil
+	}
+	durations := make([]time.Duration, len(test.Suite.Tests))
+	known := 0
+	var knownTotal time.Duration
+	for i, name := range test.Suite.Tests {
+		if duration, ok := d[timingKey(test.ExecutionConfig.Name, test.Suite.Name, name)]; ok {
+			durations[i] = duration
+			knownTotal += duration
+			known++
+		}
+	}
+	var total time.Duration
+	for i, name := range test.Suite.Tests {
+		if _, ok := d[timingKey(test.ExecutionConfig.Name, test.Suite.Name, name)]; !ok {
+			durations[i] = knownTotal / time.Duration(known)
+		}
+		total += durations[i]
+	}
+
+	minSize = utils.Max(minSize, 1)
+	var result [][]string
+	var current []string
+	var currentTime time.Duration
+	target := total / time.Duration(parts)
+	for i, name := range test.Suite.Tests {
+		current = append(current, name)
+		currentTime += durations[i]
+		rest := len(test.Suite.Tests) - i - 1
+		if currentTime >= target && len(current) >= minSize && rest >= minSize && len(result)+1 < parts {
+			result = append(result, current)
+			current = nil
+			currentTime = 0
+		}
+	}
+	return append(result, current)
+}
+
+// estimateRemaining - estimates time required to complete waiting and running tasks by durations of previous runs.
+func (ctx *executionContext) estimateRemaining() (time.Duration, bool) {
+	if len(ctx.timings) == 0 {
+		return 0, false
+	}
+	ctx.RLock()
+	defer ctx.RUnlock()
+	var total time.Duration
+	for _, task := range ctx.tasks {
+		total += ctx.timings.estimate(task.test)
+	}
+	for _, task := range ctx.running {
+		if left := ctx.timings.estimate(task.test) - time.Since(task.test.Started); left > 0 {
+			total += left
+		}
+	}
+	instances := 0
+	for _, cl := range ctx.clusters {
+		instances += len(cl.instances)
+	}
+	return total / time.Duration(utils.Max(instances, 1)), true
+}
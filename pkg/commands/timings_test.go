@@ -0,0 +1,107 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/model"
+	"github.com/networkservicemesh/cloudtest/pkg/tests"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+func newTimingsConfig(tmpDir string) *config.CloudTestConfig {
+	testConfig := config.NewCloudTestConfig()
+	testConfig.Timeout = 300
+	testConfig.ConfigRoot = path.Join(tmpDir, "root")
+	testConfig.Statistics.Enabled = false
+	testConfig.Timings.File = path.Join(tmpDir, "timings.json")
+	createProvider(testConfig, "a_provider", "echo starting").Instances = 3
+	testConfig.Executions = append(testConfig.Executions, &config.Execution{
+		Name:            "simple",
+		Timeout:         2,
+		PackageRoot:     "../tests/sample",
+		ClusterSelector: []string{"a_provider"},
+	})
+	return testConfig
+}
+
+func TestTimingsAreStored(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), t.Name())
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	testConfig := newTimingsConfig(tmpDir)
+	_, err = PerformTesting(context.Background(), testConfig, &tests.TestValidationFactory{})
+	require.Error(t, err)
+
+	timings, err := loadTimings(testConfig.Timings.File)
+	require.NoError(t, err)
+	require.Contains(t, timings, "simple/TestPass")
+	require.Contains(t, timings, "simple/TestFail")
+	require.True(t, timings["simple/TestTimeout"] >= 2*time.Second)
+}
+
+func TestTimingsScheduleLongestFirst(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), t.Name())
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	testConfig := newTimingsConfig(tmpDir)
+	require.NoError(t, testDurations{
+		"simple/TestPass":    time.Second,
+		"simple/TestFail":    30 * time.Second,
+		"simple/TestTimeout": 5 * time.Second,
+	}.save(testConfig.Timings.File))
+
+	plan, err := PerformPlan(testConfig, &tests.TestValidationFactory{})
+	require.NoError(t, err)
+
+	var names []string
+	for _, task := range plan.Tasks {
+		names = append(names, task.Name)
+	}
+	require.Equal(t, []string{"TestFail", "TestTimeout", "TestPass"}, names)
+	// 36 seconds of tests could be done with 2 instances during the longest 30 seconds test.
+	require.Equal(t, 2, plan.Clusters[0].Instances)
+}
+
+func TestSplitSuiteByDuration(t *testing.T) {
+	test := &model.TestEntry{
+		Name:            "TestSuite",
+		ExecutionConfig: &config.Execution{Name: "simple"},
+		Suite:           &model.Suite{Name: "TestSuite", Tests: []string{"TestA", "TestB", "TestC", "TestD", "TestE"}},
+	}
+	timings := testDurations{
+		"simple/TestSuite/TestA": 10 * time.Second,
+		"simple/TestSuite/TestB": time.Second,
+		"simple/TestSuite/TestC": time.Second,
+		"simple/TestSuite/TestD": 2 * time.Second,
+	}
+	// TestE has no history and treated as average 3.5 seconds.
+	require.Equal(t, [][]string{{"TestA"}, {"TestB", "TestC", "TestD", "TestE"}}, timings.splitSuiteByDuration(test, 2, 0))
+	require.Equal(t, [][]string{{"TestA", "TestB"}, {"TestC", "TestD", "TestE"}}, timings.splitSuiteByDuration(test, 2, 2))
+	require.Nil(t, testDurations{}.splitSuiteByDuration(test, 2, 0))
+}
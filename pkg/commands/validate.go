@@ -0,0 +1,376 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+	yamlnode "gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/execmanager"
+	"github.com/networkservicemesh/cloudtest/pkg/external"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+var yamlLinePattern = regexp.MustCompile(`line (\d+): (.*)$`)
+
+// ConfigError - a problem found in configuration file.
+type ConfigError struct {
+	File    string
+	Line    int
+	Message string
+}
+
+func (e *ConfigError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.File, e.Message)
+}
+
+// configLocation - a file and a yaml node configuration element is defined with.
+type configLocation struct {
+	file string
+	node *yamlnode.Node
+}
+
+// configFiles - a root configuration merged with all imports, with locations of every execution and provider.
+type configFiles struct {
+	config     *config.CloudTestConfig
+	root       configLocation
+	executions map[*config.Execution]configLocation
+	providers  map[*config.ClusterProviderConfig]configLocation
+	errors     []*ConfigError
+}
+
+// CloudTestValidate - validates configuration file passed by command line and prints all problems found.
+func CloudTestValidate(cmd *cloudTestCmd) {
+	if cmd.cmdOptions.ConfigFile == "" {
+		cmd.cmdOptions.ConfigFile = defaultConfigFile
+	}
+	files, err := readConfigFiles(cmd.cmdOptions.ConfigFile)
+	if err != nil {
+		logrus.Errorf("Failed to read config %v", err)
+		os.Exit(1)
+	}
+	problems := files.validate(cmd.cmdOptions)
+	for _, p := range problems {
+		fmt.Println(p.Error())
+	}
+	if len(problems) > 0 {
+		logrus.Errorf("Configuration %v has %d problem(s)", cmd.cmdOptions.ConfigFile, len(problems))
+		os.Exit(1)
+	}
+	fmt.Printf("Configuration %v is valid\n", cmd.cmdOptions.ConfigFile)
+}
+
+func readConfigFiles(fileName string) (*configFiles, error) {
+	files := &configFiles{
+		config:     config.NewCloudTestConfig(),
+		executions: map[*config.Execution]configLocation{},
+		providers:  map[*config.ClusterProviderConfig]configLocation{},
+	}
+	root, err := files.readFile(fileName, files.config)
+	if err != nil {
+		return nil, err
+	}
+	files.root = configLocation{file: fileName, node: root}
+
+	for i, imp := range files.config.Imports {
+		imports, err := resolveImport(imp)
+		if err != nil {
+			files.errors = append(files.errors, newLocatedError(files.root, fmt.Sprintf("invalid import %v: %v", imp, err), "import", i))
+			continue
+		}
+		for _, f := range imports {
+			importConfig := &config.CloudTestConfig{}
+			if _, err := files.readFile(f, importConfig); err != nil {
+				files.errors = append(files.errors, newLocatedError(files.root, fmt.Sprintf("failed to read import %v: %v", f, err), "import", i))
+				continue
+			}
+			files.config.Executions = append(files.config.Executions, importConfig.Executions...)
+			files.config.Providers = append(files.config.Providers, importConfig.Providers...)
+		}
+	}
+	return files, nil
+}
+
+// readFile - parses file into target, all unknown fields and locations of executions and providers are collected.
+func (files *configFiles) readFile(fileName string, target *config.CloudTestConfig) (*yamlnode.Node, error) {
+	content, err := ioutil.ReadFile(filepath.Clean(fileName))
+	if err != nil {
+		return nil, err
+	}
+	if err = parseConfig(target, content); err != nil {
+		return nil, err
+	}
+	if err = yaml.UnmarshalStrict(content, config.NewCloudTestConfig()); err != nil {
+		messages := []string{err.Error()}
+		if typeErr, ok := err.(*yaml.TypeError); ok {
+			messages = typeErr.Errors
+		}
+		for _, msg := range messages {
+			files.errors = append(files.errors, newConfigError(fileName, msg))
+		}
+	}
+
+	root := &yamlnode.Node{}
+	if err = yamlnode.Unmarshal(content, root); err != nil {
+		return nil, err
+	}
+	for i, e := range target.Executions {
+		files.executions[e] = configLocation{file: fileName, node: lookupNode(root, "executions", i)}
+	}
+	for i, p := range target.Providers {
+		files.providers[p] = configLocation{file: fileName, node: lookupNode(root, "providers", i)}
+	}
+	return root, nil
+}
+
+func newConfigError(fileName, msg string) *ConfigError {
+	if match := yamlLinePattern.FindStringSubmatch(msg); match != nil {
+		line, _ := strconv.Atoi(match[1])
+		return &ConfigError{File: fileName, Line: line, Message: match[2]}
+	}
+	return &ConfigError{File: fileName, Message: msg}
+}
+
+func newLocatedError(location configLocation, msg string, path ...interface{}) *ConfigError {
+	return &ConfigError{File: location.file, Line: lookupLine(location.node, path...), Message: msg}
+}
+
+// validate - checks cross references between executions and providers and configuration of every provider
+// could be enabled with passed options.
+func (files *configFiles) validate(options *Options) []*ConfigError {
+	problems := append([]*ConfigError{}, files.errors...)
+	addError := func(location configLocation, msg string, path ...interface{}) {
+		problems = append(problems, newLocatedError(location, msg, path...))
+	}
+
+	providerNames := map[string]bool{}
+	for _, p := range files.config.Providers {
+		location := files.providers[p]
+		if p.Name == "" {
+			addError(location, "provider name should be specified")
+		} else if providerNames[p.Name] {
+			addError(location, fmt.Sprintf("provider %v is already defined", p.Name), "name")
+		}
+		if p.TestsConcurrency < 0 {
+			addError(location, fmt.Sprintf("provider %v: tests-per-instance-concurrency should not be negative", p.Name), "tests-per-instance-concurrency")
+		}
+		providerNames[p.Name] = true
+	}
+
+	executionNames := map[string]bool{}
+	for _, e := range files.config.Executions {
+		location := files.executions[e]
+		if e.Name == "" {
+			addError(location, "execution name should be specified")
+		} else if executionNames[e.Name] {
+			addError(location, fmt.Sprintf("execution %v is already defined", e.Name), "name")
+		}
+		executionNames[e.Name] = true
+
+		for i, name := range e.ClusterSelector {
+			if !providerNames[name] {
+				addError(location, fmt.Sprintf("execution %v: cluster-selector refers to unknown provider %v", e.Name, name), "cluster-selector", i)
+			}
+		}
+		if e.ClusterCount > 1 && len(e.ClusterSelector) < e.ClusterCount {
+			addError(location, fmt.Sprintf("execution %v: cluster-count is %d, but only %d cluster(s) are selected",
+				e.Name, e.ClusterCount, len(e.ClusterSelector)), "cluster-count")
+		}
+		if e.MaxParallel < 0 || e.MaxParallel > 0 && e.MaxParallel < utils.Max(e.ClusterCount, 1) {
+			addError(location, fmt.Sprintf("execution %v: max-parallel is %d, but every task requires %d cluster instance(s)",
+				e.Name, e.MaxParallel, utils.Max(e.ClusterCount, 1)), "max-parallel")
+		}
+		if e.Container != nil && e.Kind != "shell" && e.Kind != "external" {
+			addError(location, fmt.Sprintf("execution %v: container is supported only by shell and external executions", e.Name), "container")
+		} else if e.Container != nil && e.Container.Image == "" {
+			addError(location, fmt.Sprintf("execution %v: container image should be specified", e.Name), "container")
+		}
+		if format := external.Format(e.External); format != external.FormatJUnit && format != external.FormatTAP {
+			addError(location, fmt.Sprintf("execution %v: unknown report format %v", e.Name, format), "external", "format")
+		}
+		if clusterCount := utils.Max(e.ClusterCount, 1); len(e.ClusterEnv) > 0 && len(e.ClusterEnv) != clusterCount {
+			addError(location, fmt.Sprintf("execution %v: cluster-env has %d variable(s), but %d cluster(s) are required",
+				e.Name, len(e.ClusterEnv), clusterCount), "cluster-env")
+		}
+		if e.Diagnostics != nil {
+			if _, err := labels.Parse(e.Diagnostics.LabelSelector); err != nil {
+				addError(location, fmt.Sprintf("execution %v: invalid diagnostics label selector: %v", e.Name, err), "diagnostics", "label-selector")
+			}
+		}
+	}
+
+	for _, e := range files.config.Executions {
+		for i, name := range e.DependsOn {
+			if !executionNames[name] {
+				addError(files.executions[e], fmt.Sprintf("execution %v: depends-on refers to unknown execution %v", e.Name, name), "depends-on", i)
+			}
+		}
+	}
+	if cycle := findDependencyCycle(files.config.Executions); cycle != nil {
+		for _, e := range files.config.Executions {
+			if e.Name == cycle[0] {
+				addError(files.executions[e], fmt.Sprintf("execution %v: dependency cycle %v", e.Name, strings.Join(cycle, " -> ")), "depends-on")
+				break
+			}
+		}
+	}
+
+	sharding := files.config.Sharding
+	if options.ShardIndex > 0 {
+		sharding.Index = options.ShardIndex
+	}
+	if options.ShardTotal > 0 {
+		sharding.Total = options.ShardTotal
+	}
+	if sharding.Total > 1 && (sharding.Index < 1 || sharding.Index > sharding.Total) {
+		addError(files.root, fmt.Sprintf("shard index %v should be in range from 1 to %v", sharding.Index, sharding.Total), "sharding")
+	}
+
+	for i, entry := range files.config.Quarantine.Tests {
+		if _, err := newQuarantineRule(entry, time.Now()); err != nil {
+			addError(files.root, err.Error(), "quarantine", "tests", i)
+		}
+	}
+	if fileName := files.config.Quarantine.File; fileName != "" {
+		if _, err := newQuarantine(&config.QuarantineConfig{File: fileName}, time.Now()); err != nil {
+			addError(files.root, err.Error(), "quarantine", "file")
+		}
+	}
+	for i, rule := range files.config.FailureRules {
+		if _, err := newFailureRule(rule); err != nil {
+			addError(files.root, err.Error(), "failure-rules", i)
+		}
+	}
+	if policy := files.config.Flaky.Policy; policy != "" && policy != flakyPolicyPass && policy != flakyPolicyFail {
+		addError(files.root, fmt.Sprintf("unknown flaky policy %v, should be %v or %v", policy, flakyPolicyPass, flakyPolicyFail), "flaky", "policy")
+	}
+
+	return append(problems, files.validateProviders(options)...)
+}
+
+func (files *configFiles) validateProviders(options *Options) (problems []*ConfigError) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "cloudtest-validate")
+	if err != nil {
+		return []*ConfigError{newLocatedError(files.root, fmt.Sprintf("failed to create temporary folder: %v", err))}
+	}
+	defer utils.ClearFolder(tmpDir, false)
+
+	clusterProviders, err := createClusterProviders(execmanager.NewExecutionManager(tmpDir))
+	if err != nil {
+		return []*ConfigError{newLocatedError(files.root, fmt.Sprintf("failed to create cluster providers: %v", err))}
+	}
+	for _, p := range files.config.Providers {
+		location := files.providers[p]
+		provider, ok := clusterProviders[p.Kind]
+		if !ok {
+			problems = append(problems, newLocatedError(location, fmt.Sprintf("provider %v: unknown kind %v", p.Name, p.Kind), "kind"))
+			continue
+		}
+		// Only providers could be enabled are checked, since they could require some environment to be present.
+		enabled := p.Enabled || utils.Contains(options.Clusters, p.Name)
+		enabled = enabled && (len(options.Clusters) == 0 || utils.Contains(options.Clusters, p.Name))
+		enabled = enabled && (len(options.Kinds) == 0 || utils.Contains(options.Kinds, p.Kind))
+		if !enabled {
+			continue
+		}
+		if err := provider.ValidateConfig(p); err != nil {
+			problems = append(problems, newLocatedError(location, fmt.Sprintf("provider %v: %v", p.Name, err)))
+		}
+	}
+	return problems
+}
+
+// lookupLine - returns a line of element by path, or a line of closest parent element if path is not found.
+// For mapping keys, a line of key is returned.
+func lookupLine(node *yamlnode.Node, path ...interface{}) int {
+	line := 0
+	for i := range path {
+		current := lookupNode(node, path[:i]...)
+		if current == nil {
+			return line
+		}
+		line = current.Line
+		if key, ok := path[i].(string); ok {
+			if idx := lookupKey(current, key); idx >= 0 {
+				line = current.Content[idx].Line
+			}
+		}
+	}
+	if len(path) > 0 {
+		if _, ok := path[len(path)-1].(string); ok {
+			return line
+		}
+	}
+	if current := lookupNode(node, path...); current != nil {
+		line = current.Line
+	}
+	return line
+}
+
+// lookupNode - returns a node by path of mapping keys and sequence indexes.
+func lookupNode(node *yamlnode.Node, path ...interface{}) *yamlnode.Node {
+	if node != nil && node.Kind == yamlnode.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	for _, p := range path {
+		if node == nil {
+			return nil
+		}
+		switch key := p.(type) {
+		case string:
+			idx := lookupKey(node, key)
+			if idx < 0 {
+				return nil
+			}
+			node = node.Content[idx+1]
+		case int:
+			if node.Kind != yamlnode.SequenceNode || key >= len(node.Content) {
+				return nil
+			}
+			node = node.Content[key]
+		}
+	}
+	return node
+}
+
+// lookupKey - returns an index of key node inside of mapping node, or -1 if key is not found.
+func lookupKey(node *yamlnode.Node, key string) int {
+	if node.Kind != yamlnode.MappingNode {
+		return -1
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
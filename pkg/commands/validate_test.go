@@ -0,0 +1,256 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+const validRootConfig = `---
+version: 1.0
+providers:
+  - name: a_provider
+    kind: shell
+    enabled: true
+    scripts:
+      config: echo ./.tests/config
+      start: echo started
+      stop: echo stopped
+executions:
+  - name: simple
+    cluster-selector:
+      - a_provider
+`
+
+const invalidRootConfig = `---
+version: 1.0
+providers:
+  - name: a_provider
+    kind: shell
+    enabled: true
+    scripts:
+      start: echo started
+  - name: b_provider
+    kind: unknown
+executions:
+  - name: simple
+    cluster-selecter:
+      - a_provider
+  - name: interdomain
+    cluster-count: 2
+    cluster-selector:
+      - a_provider
+      - c_provider
+    cluster-env:
+      - KUBECONFIG
+import:
+  - %s
+`
+
+const invalidImportConfig = `---
+executions:
+  - name: imported
+    test-retry: 3
+    cluster-selector:
+      - d_provider
+`
+
+func writeConfig(t *testing.T, dir, name, content string) string {
+	fileName := path.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(fileName, []byte(content), os.ModePerm))
+	return fileName
+}
+
+func TestValidateCorrectConfig(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), t.Name())
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	files, err := readConfigFiles(writeConfig(t, tmpDir, "config.yaml", validRootConfig))
+	require.NoError(t, err)
+	require.Empty(t, files.validate(&Options{}))
+}
+
+func TestValidateReportsAllProblems(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), t.Name())
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	importFile := writeConfig(t, tmpDir, "import.yaml", invalidImportConfig)
+	rootFile := writeConfig(t, tmpDir, "config.yaml", fmt.Sprintf(invalidRootConfig, importFile))
+
+	files, err := readConfigFiles(rootFile)
+	require.NoError(t, err)
+
+	var problems []string
+	for _, p := range files.validate(&Options{}) {
+		problems = append(problems, p.Error())
+	}
+	require.ElementsMatch(t, []string{
+		rootFile + ":13: field cluster-selecter not found in type config.Execution",
+		importFile + ":4: field test-retry not found in type config.Execution",
+		rootFile + ":19: execution interdomain: cluster-selector refers to unknown provider c_provider",
+		rootFile + ":20: execution interdomain: cluster-env has 1 variable(s), but 2 cluster(s) are required",
+		importFile + ":6: execution imported: cluster-selector refers to unknown provider d_provider",
+		rootFile + ":10: provider b_provider: unknown kind unknown",
+		rootFile + ":4: provider a_provider: invalid config location",
+	}, problems)
+}
+
+func TestValidateSkipsDisabledProviders(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), t.Name())
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	files, err := readConfigFiles(writeConfig(t, tmpDir, "config.yaml", validRootConfig))
+	require.NoError(t, err)
+	files.config.Providers[0].Scripts = map[string]string{}
+
+	require.Len(t, files.validate(&Options{}), 1)
+	require.Empty(t, files.validate(&Options{Kinds: []string{"packet"}}))
+	require.Empty(t, files.validate(&Options{Clusters: []string{"b_provider"}}))
+}
+
+func TestValidateContainer(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), t.Name())
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	files, err := readConfigFiles(writeConfig(t, tmpDir, "config.yaml", validRootConfig))
+	require.NoError(t, err)
+	execution := files.config.Executions[0]
+
+	execution.Container = &config.ContainerConfig{Image: "tools:latest"}
+	problems := files.validate(&Options{})
+	require.Len(t, problems, 1)
+	require.Contains(t, problems[0].Error(), "execution simple: container is supported only by shell and external executions")
+
+	execution.Kind = "shell"
+	require.Empty(t, files.validate(&Options{}))
+
+	execution.Container.Image = ""
+	problems = files.validate(&Options{})
+	require.Len(t, problems, 1)
+	require.Contains(t, problems[0].Error(), "execution simple: container image should be specified")
+}
+
+func TestValidateExternalFormat(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), t.Name())
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	files, err := readConfigFiles(writeConfig(t, tmpDir, "config.yaml", validRootConfig))
+	require.NoError(t, err)
+	execution := files.config.Executions[0]
+	execution.Kind = "external"
+
+	execution.External = &config.ExternalConfig{Format: "tap"}
+	require.Empty(t, files.validate(&Options{}))
+
+	execution.External.Format = "xunit"
+	problems := files.validate(&Options{})
+	require.Len(t, problems, 1)
+	require.Contains(t, problems[0].Error(), "execution simple: unknown report format xunit")
+}
+
+func TestValidateFlakyPolicy(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), t.Name())
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	files, err := readConfigFiles(writeConfig(t, tmpDir, "config.yaml", validRootConfig))
+	require.NoError(t, err)
+
+	files.config.Flaky.Policy = flakyPolicyFail
+	require.Empty(t, files.validate(&Options{}))
+
+	files.config.Flaky.Policy = "ignore"
+	problems := files.validate(&Options{})
+	require.Len(t, problems, 1)
+	require.Contains(t, problems[0].Error(), "unknown flaky policy ignore")
+}
+
+func TestValidateFailureRules(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), t.Name())
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	files, err := readConfigFiles(writeConfig(t, tmpDir, "config.yaml", validRootConfig))
+	require.NoError(t, err)
+
+	files.config.FailureRules = []*config.FailureRule{
+		{Pattern: "Failed to pull image", Category: "INFRA"},
+		{Pattern: "panic(", Category: "PANIC"},
+	}
+	problems := files.validate(&Options{})
+	require.Len(t, problems, 1)
+	require.Contains(t, problems[0].Error(), "failure rule panic(: invalid pattern")
+}
+
+func TestValidateDiagnostics(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), t.Name())
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	files, err := readConfigFiles(writeConfig(t, tmpDir, "config.yaml", validRootConfig))
+	require.NoError(t, err)
+
+	files.config.Executions[0].Diagnostics = &config.DiagnosticsConfig{LabelSelector: "app in (nsmgr, forwarder)"}
+	require.Empty(t, files.validate(&Options{}))
+
+	files.config.Executions[0].Diagnostics.LabelSelector = "app in nsmgr"
+	problems := files.validate(&Options{})
+	require.Len(t, problems, 1)
+	require.Contains(t, problems[0].Error(), "execution simple: invalid diagnostics label selector")
+}
+
+func TestValidateDependencies(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), t.Name())
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	rootFile := writeConfig(t, tmpDir, "config.yaml", `---
+executions:
+  - name: basic
+    depends-on:
+      - interdomain
+  - name: interdomain
+    depends-on:
+      - basic
+      - unknown
+`)
+	files, err := readConfigFiles(rootFile)
+	require.NoError(t, err)
+
+	var problems []string
+	for _, p := range files.validate(&Options{}) {
+		problems = append(problems, p.Error())
+	}
+	require.ElementsMatch(t, []string{
+		rootFile + ":9: execution interdomain: depends-on refers to unknown execution unknown",
+		rootFile + ":4: execution basic: dependency cycle basic -> interdomain -> basic",
+	}, problems)
+}
@@ -19,7 +19,7 @@ package config
 
 type ClusterProviderConfig struct {
 	Name       string            `yaml:"name"`       // name of provider, GKE, Azure, etc.
-	Kind       string            `yaml:"kind"`       // register provider type, 'shell', 'packet'
+	Kind       string            `yaml:"kind"`       // register provider type, 'shell', 'packet', 'kind', 'static', 'exec'
 	Instances  int               `yaml:"instances"`  // Number of required instances, executions will be split between instances.
 	Timeout    int               `yaml:"timeout"`    // Timeout for start, stop
 	RetryCount int               `yaml:"retry"`      // A count of start retrying steps.
@@ -32,6 +32,12 @@ type ClusterProviderConfig struct {
 	EnvCheck   []string          `yaml:"env-check"`  // Check if environment has required environment variables present.
 	Packet     *PacketConfig     `yaml:"packet"`     // A Packet provider configuration
 	TestDelay  int               `yaml:"test-delay"` // Delay between tests of this cluster will be executed in second.
+
+	TestsConcurrency int `yaml:"tests-per-instance-concurrency"` // A number of tests could be executed on one instance at once, every test gets own namespace.
+
+	KindCluster *KindConfig   `yaml:"kind-cluster"` // A kind provider configuration
+	Static      *StaticConfig `yaml:"static"`       // A static provider configuration
+	Exec        *ExecConfig   `yaml:"exec"`         // An exec provider configuration
 }
 
 type ExecutionSource struct {
@@ -43,10 +49,11 @@ type Execution struct {
 	Source          ExecutionSource `yaml:"source"`           // A source for tests execution
 	Before          string          `yaml:"before"`           // A script to execute against required cluster, called before run tasks from execution.
 	After           string          `yaml:"after"`            // A script to execute against required cluster, called when all tasks from execution are done on cluster instance.
-	Kind            string          `yaml:"kind"`             // Execution kind, default is 'gotest', 'shell' could be used for pure shell tests.
+	Kind            string          `yaml:"kind"`             // Execution kind, default is 'gotest', 'shell' could be used for pure shell tests, 'ginkgo' for Ginkgo suites, 'external' for shell tests writing JUnit or TAP reports.
 	Name            string          `yaml:"name"`             // Execution name
 	OnlyRun         []string        `yaml:"only-run"`         // If non-empty, only run the listed tests
 	PackageRoot     string          `yaml:"root"`             // A package root for this test execution, default .
+	TestBinary      string          `yaml:"test-binary"`      // A prebuilt test binary, if empty package tests are compiled once with 'go test -c'.
 	Timeout         int64           `yaml:"timeout"`          // Individual test timeout, "60" passed to gotest, in seconds
 	ExtraOptions    []string        `yaml:"extra-options"`    // Extra options to pass to gotest
 	ClusterCount    int             `yaml:"cluster-count"`    // A number of clusters required for this execution, default 1
@@ -55,9 +62,17 @@ type Execution struct {
 	Env             []string        `yaml:"env"`              // Additional environment variables
 	Run             string          `yaml:"run"`              // A script to execute against required cluster
 	OnFail          string          `yaml:"on-fail"`          // A script to execute against required cluster, called if task failed
+	DependsOn       []string        `yaml:"depends-on"`       // Executions should pass before tests of this execution are started.
+	Priority        int             `yaml:"priority"`         // Tasks of executions with higher priority get cluster instances first.
+	MaxParallel     int             `yaml:"max-parallel"`     // A maximum number of cluster instances tasks of execution could use at once, unlimited if 0.
 
 	ConcurrencyRetry int64 `yaml:"test-retry-count"` // A count of times, same test will be executed to find concurrency issues
 	TestsFound       int   `yaml:"-"`                // Number of tests found for the config
+
+	Ginkgo      *GinkgoConfig      `yaml:"ginkgo"`      // A configuration of ginkgo execution.
+	Container   *ContainerConfig   `yaml:"container"`   // A container commands of shell execution are executed in.
+	External    *ExternalConfig    `yaml:"external"`    // A configuration of reports written by external execution.
+	Diagnostics *DiagnosticsConfig `yaml:"diagnostics"` // Kubernetes diagnostics collected on failure of test.
 }
 
 type RetestConfig struct {
@@ -80,20 +95,43 @@ type CloudTestConfig struct {
 	Providers  []*ClusterProviderConfig `yaml:"providers"`
 	ConfigRoot string                   `yaml:"root"` // A provider stored configurations root.
 	Reporting  struct {
-		JUnitReportFile string `yaml:"junit-report"` // A junit report file location, relative to test root folder.
+		JUnitReportFile     string `yaml:"junit-report"`          // A junit report file location, relative to test root folder.
+		HTMLReportFile      string `yaml:"html-report"`           // A HTML report file location, relative to test root folder.
+		JSONReportFile      string `yaml:"json-report"`           // A JSON summary file location, relative to test root folder.
+		JSONConfig          bool   `yaml:"json-config"`           // Embed effective configuration into JSON summary.
+		MarkdownReportFile  string `yaml:"markdown-report"`       // A Markdown summary file location, relative to test root folder.
+		MarkdownOutputLines int    `yaml:"markdown-output-lines"` // A number of last output lines of failed tests.
+		MarkdownMaxSize     int    `yaml:"markdown-max-size"`     // A maximum size of Markdown report in bytes.
 	} `yaml:"reporting"` // A reporting options.
 	HealthCheck []*HealthCheckConfig `yaml:"health-check"` // Health checks options.
 	Executions  []*Execution         `yaml:"executions"`
 	Timeout     int64                `yaml:"timeout"` // Global timeout in seconds
 	Imports     []string             `yaml:"import"`  // A set of configurations for import
 
-	RetestConfig RetestConfig `yaml:"retest"`
+	RetestConfig RetestConfig   `yaml:"retest"`
+	FailureRules []*FailureRule `yaml:"failure-rules"` // Rules classifying failures, first matching rule is applied.
 
 	Statistics struct {
 		Interval int64 `yaml:"interval"` // A statistics printing timeout, default 60 seconds
 		Enabled  bool  `yaml:"enabled"`  // A way to disable printing of statistics
 	} `yaml:"statistics"` // Statistics options
 
+	Sharding struct {
+		Index     int    `yaml:"index"`     // A shard to execute, from 1 to total.
+		Total     int    `yaml:"total"`     // A number of shards tests are split to, sharding is disabled if less than 2.
+		Durations string `yaml:"durations"` // A JUnit report of previous run, used to balance shards by test durations.
+	} `yaml:"sharding"` // Sharding options
+
+	Timings struct {
+		File string `yaml:"file"` // A file to store durations of tests between runs, used to schedule longest tests first.
+	} `yaml:"timings"` // Timings database options
+
+	Quarantine QuarantineConfig `yaml:"quarantine"` // Known broken tests, their failures are reported as skipped.
+
+	Flaky struct {
+		Policy string `yaml:"policy"` // A result of flaky tests, pass or fail, flaky tests are reported by their status if empty.
+	} `yaml:"flaky"` // Flaky tests options
+
 	ShuffleTests            bool     `yaml:"shuffle-enabled"`    // Shuffle tests before assignment
 	OnlyRun                 []string `yaml:"only-run"`           // If non-empty, only run the listed tests
 	FailedTestsLimit        int      `yaml:"failed-tests-limit"` // If non-zero, terminates testing after failed tests limit is reached
@@ -0,0 +1,24 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// ContainerConfig - a configuration of container shell tests commands are executed in with docker compatible CLI.
+type ContainerConfig struct {
+	Image   string   `yaml:"image"`   // An image to run commands in.
+	Runtime string   `yaml:"runtime"` // A container CLI, 'docker' by default, 'podman' could be used as well.
+	Args    []string `yaml:"args"`    // Extra arguments passed to run command, like '--network=host'.
+}
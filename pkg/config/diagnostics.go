@@ -0,0 +1,26 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// DiagnosticsConfig - a configuration of Kubernetes diagnostics collected into ARTIFACTS_DIR on failure or timeout of test.
+type DiagnosticsConfig struct {
+	Namespaces    []string `yaml:"namespaces"`     // Namespaces to collect, a test namespace or all namespaces if empty.
+	LabelSelector string   `yaml:"label-selector"` // A label selector of pods to collect, all pods if empty.
+	LogLines      int64    `yaml:"log-lines"`      // A number of last log lines of every container, 1000 by default.
+	MaxFileSize   int64    `yaml:"max-file-size"`  // A maximum size of every file in bytes, 1 MiB by default.
+	MaxSize       int64    `yaml:"max-size"`       // A maximum size of diagnostics of cluster in bytes, 10 MiB by default.
+}
@@ -0,0 +1,23 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// ExternalConfig - a configuration of external execution, run script writes a report of test framework into ARTIFACTS_DIR.
+type ExternalConfig struct {
+	Format string `yaml:"format"` // A report format, 'junit' by default, 'tap' could be used as well.
+	Report string `yaml:"report"` // A glob pattern of report files in ARTIFACTS_DIR, '*.xml' for JUnit and '*.tap' for TAP by default.
+}
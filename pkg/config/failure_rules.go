@@ -0,0 +1,25 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// FailureRule - a rule classifying failures by output of tests and logs of cluster starts.
+type FailureRule struct {
+	Pattern  string `yaml:"pattern"`  // A regular expression matched against every line of output.
+	Category string `yaml:"category"` // A failure category, like cluster, infra or bug, used as JUnit failure type.
+	Message  string `yaml:"message"`  // A short human readable description of failure.
+	Target   string `yaml:"target"`   // 'test' or 'cluster' to match only test output or cluster logs, both if empty.
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// GinkgoConfig - a configuration of ginkgo execution, specs of Ginkgo suite are found and executed with ginkgo CLI.
+type GinkgoConfig struct {
+	Binary      string   `yaml:"binary"`       // A ginkgo binary, 'ginkgo' found in PATH by default.
+	LabelFilter string   `yaml:"label-filter"` // A label filter expression specs should match.
+	Focus       []string `yaml:"focus"`        // Only specs matching one of regular expressions are executed.
+	Skip        []string `yaml:"skip"`         // Specs matching any of regular expressions are not executed.
+	Args        []string `yaml:"args"`         // Extra arguments passed to ginkgo.
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// KindConfig - a configuration of kind provider.
+type KindConfig struct {
+	Binary     string   `yaml:"binary"`      // A kind binary to use, default is 'kind' found in PATH.
+	Config     string   `yaml:"config"`      // A location of kind cluster configuration file.
+	ConfigData string   `yaml:"config-data"` // An embedded kind cluster configuration, used if config location is not specified.
+	NodeImage  string   `yaml:"node-image"`  // A node image to create cluster with, kind default is used if empty.
+	Images     []string `yaml:"images"`      // Docker images to load into cluster nodes after cluster is created.
+	Wait       int      `yaml:"wait"`        // Seconds to wait for control plane to be ready during cluster creation.
+	// Delete 'cloudtest-' clusters left by previous runs, should not be enabled if other cloudtest processes use same host.
+	CleanupLeaked bool `yaml:"cleanup-leaked"`
+}
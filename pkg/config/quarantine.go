@@ -0,0 +1,32 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// QuarantineConfig - known broken tests, they are executed, but their failures are reported as skipped.
+type QuarantineConfig struct {
+	File  string             `yaml:"file"`  // A YAML file with a list of quarantine entries.
+	Tests []*QuarantineEntry `yaml:"tests"` // Quarantine entries.
+}
+
+// QuarantineEntry - a quarantined test.
+type QuarantineEntry struct {
+	Pattern   string `yaml:"pattern"`   // A regular expression matched against test name.
+	Execution string `yaml:"execution"` // An execution name, entry is applied to all executions if empty.
+	Reason    string `yaml:"reason"`    // A reason test is quarantined, it is used as skip message.
+	Owner     string `yaml:"owner"`     // A person or team responsible for fixing of test.
+	Expires   string `yaml:"expires"`   // A date in YYYY-MM-DD format, entry is not applied after it.
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// StaticClusterConfig - an existing cluster used as an instance of static provider.
+type StaticClusterConfig struct {
+	KubeConfig string `yaml:"kubeconfig"` // A location of kubeconfig, KUBECONFIG environment variable or ~/.kube/config is used if empty.
+	Context    string `yaml:"context"`    // A context of kubeconfig to use, current context is used if empty.
+}
+
+// StaticConfig - a configuration of static provider.
+type StaticConfig struct {
+	Clusters []*StaticClusterConfig `yaml:"clusters"` // Existing clusters, every cluster is an instance of provider.
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/cloudtest/pkg/reporting"
+)
+
+// junitSuite - a test suite of JUnit report, root element could be either testsuites or testsuite.
+type junitSuite struct {
+	XMLName   xml.Name
+	TestCases []*junitTestCase `xml:"testcase"`
+	Suites    []*junitSuite    `xml:"testsuite"`
+}
+
+type junitTestCase struct {
+	Classname string       `xml:"classname,attr"`
+	Name      string       `xml:"name,attr"`
+	Time      string       `xml:"time,attr"`
+	Failure   *junitResult `xml:"failure"`
+	Error     *junitResult `xml:"error"`
+	Skipped   *junitResult `xml:"skipped"`
+	SystemOut string       `xml:"system-out"`
+	SystemErr string       `xml:"system-err"`
+}
+
+type junitResult struct {
+	Message  string `xml:"message,attr"`
+	Type     string `xml:"type,attr"`
+	Contents string `xml:",chardata"`
+}
+
+// ReadJUnit - reads test cases of JUnit XML report, errors are reported as failures.
+func ReadJUnit(fileName string) ([]*reporting.TestCase, error) {
+	content, err := ioutil.ReadFile(filepath.Clean(fileName))
+	if err != nil {
+		return nil, err
+	}
+	root := &junitSuite{}
+	if err = xml.Unmarshal(content, root); err != nil {
+		return nil, err
+	}
+	if root.XMLName.Local != "testsuites" && root.XMLName.Local != "testsuite" {
+		return nil, errors.Errorf("unexpected root element %v", root.XMLName.Local)
+	}
+	return root.testCases(nil), nil
+}
+
+func (s *junitSuite) testCases(result []*reporting.TestCase) []*reporting.TestCase {
+	for _, tc := range s.TestCases {
+		result = append(result, tc.testCase())
+	}
+	for _, child := range s.Suites {
+		result = child.testCases(result)
+	}
+	return result
+}
+
+func (tc *junitTestCase) testCase() *reporting.TestCase {
+	result := &reporting.TestCase{
+		Classname: tc.Classname,
+		Name:      tc.Name,
+		Time:      tc.Time,
+	}
+	if result.Time == "" {
+		result.Time = "0"
+	}
+	failure := tc.Failure
+	if failure == nil {
+		failure = tc.Error
+	}
+	switch {
+	case failure != nil:
+		contents := []string{failure.Contents}
+		for _, output := range []string{tc.SystemOut, tc.SystemErr} {
+			if strings.TrimSpace(output) != "" {
+				contents = append(contents, output)
+			}
+		}
+		result.Failure = &reporting.Failure{
+			Type:     "ERROR",
+			Message:  failure.Message,
+			Contents: strings.Join(contents, "\n"),
+		}
+	case tc.Skipped != nil:
+		result.SkipMessage = &reporting.SkipMessage{
+			Message: tc.Skipped.Message,
+		}
+	}
+	return result
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package external provides reading of JUnit and TAP reports written by external test frameworks.
+package external
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/reporting"
+)
+
+// Supported report formats.
+const (
+	FormatJUnit = "junit"
+	FormatTAP   = "tap"
+)
+
+// Format - returns a report format of execution.
+func Format(cfg *config.ExternalConfig) string {
+	if cfg == nil || cfg.Format == "" {
+		return FormatJUnit
+	}
+	return cfg.Format
+}
+
+// ReportFiles - returns report files of execution stored in artifacts directory.
+func ReportFiles(dir string, cfg *config.ExternalConfig) ([]string, error) {
+	pattern := "*.xml"
+	if Format(cfg) == FormatTAP {
+		pattern = "*.tap"
+	}
+	if cfg != nil && cfg.Report != "" {
+		pattern = cfg.Report
+	}
+	files, err := filepath.Glob(filepath.Join(dir, pattern))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid report pattern %v", pattern)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// ReadReports - reads test cases from all report files of execution stored in artifacts directory.
+func ReadReports(dir string, cfg *config.ExternalConfig) ([]*reporting.TestCase, error) {
+	files, err := ReportFiles(dir, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, errors.Errorf("no reports found in %v", dir)
+	}
+	var result []*reporting.TestCase
+	for _, file := range files {
+		var testCases []*reporting.TestCase
+		switch Format(cfg) {
+		case FormatJUnit:
+			testCases, err = ReadJUnit(file)
+		case FormatTAP:
+			testCases, err = ReadTAP(file)
+		default:
+			err = errors.Errorf("unknown report format %v", Format(cfg))
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read report %v", file)
+		}
+		result = append(result, testCases...)
+	}
+	return result, nil
+}
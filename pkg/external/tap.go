@@ -0,0 +1,74 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/networkservicemesh/cloudtest/pkg/reporting"
+)
+
+// tapResultRegexp - matches test result line like 'not ok 2 - description # SKIP reason'.
+var tapResultRegexp = regexp.MustCompile(`^(not )?ok\b\s*(\d*)\s*(?:-\s*)?(.*?)\s*(?:#\s*(\S+)\s*(.*))?$`)
+
+// ReadTAP - reads test cases of TAP report, diagnostic lines following failed test are added to its failure.
+// Tests with SKIP and TODO directives are reported as skipped.
+func ReadTAP(fileName string) ([]*reporting.TestCase, error) {
+	content, err := ioutil.ReadFile(filepath.Clean(fileName))
+	if err != nil {
+		return nil, err
+	}
+	var result []*reporting.TestCase
+	var last *reporting.TestCase
+	for _, line := range strings.Split(string(content), "\n") {
+		match := tapResultRegexp.FindStringSubmatch(line)
+		if match == nil {
+			// Diagnostics, like YAML blocks and comments, are related to previous test.
+			if last != nil && last.Failure != nil && strings.TrimSpace(line) != "" {
+				last.Failure.Contents += line + "\n"
+			}
+			continue
+		}
+		last = &reporting.TestCase{
+			Name: match[3],
+			Time: "0",
+		}
+		if last.Name == "" {
+			last.Name = fmt.Sprintf("test %v", match[2])
+		}
+		switch directive := strings.ToUpper(match[4]); {
+		case strings.HasPrefix(directive, "SKIP") || strings.HasPrefix(directive, "TODO"):
+			last.SkipMessage = &reporting.SkipMessage{
+				Message: match[5],
+			}
+			if last.SkipMessage.Message == "" {
+				last.SkipMessage.Message = match[4]
+			}
+		case match[1] != "":
+			last.Failure = &reporting.Failure{
+				Type:    "ERROR",
+				Message: fmt.Sprintf("Test failed: %v", last.Name),
+			}
+		}
+		result = append(result, last)
+	}
+	return result, nil
+}
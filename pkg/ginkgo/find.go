@@ -0,0 +1,129 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ginkgo
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/execmanager"
+	"github.com/networkservicemesh/cloudtest/pkg/model"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+const (
+	defaultBinary = "ginkgo"
+	stateSkipped  = "skipped"
+	statePending  = "pending"
+)
+
+// Binary - returns ginkgo binary of execution.
+func Binary(execution *config.Execution) string {
+	if execution.Ginkgo != nil && execution.Ginkgo.Binary != "" {
+		return execution.Ginkgo.Binary
+	}
+	return defaultBinary
+}
+
+// Args - returns ginkgo arguments to filter specs of execution. Focus of execution is applied only during specs
+// discovery, since found specs are focused by runner itself.
+func Args(execution *config.Execution) []string {
+	args := []string{"--no-color"}
+	if len(execution.Source.Tags) > 0 {
+		args = append(args, "--tags", strings.Join(execution.Source.Tags, ","))
+	}
+	if cfg := execution.Ginkgo; cfg != nil {
+		if cfg.LabelFilter != "" {
+			args = append(args, "--label-filter", cfg.LabelFilter)
+		}
+		for _, skip := range cfg.Skip {
+			args = append(args, "--skip", skip)
+		}
+		args = append(args, cfg.Args...)
+	}
+	return args
+}
+
+// Focus - returns a focus regular expression matching exactly passed specs.
+func Focus(specs []string) string {
+	quoted := make([]string, len(specs))
+	for i, spec := range specs {
+		quoted[i] = regexp.QuoteMeta(spec)
+	}
+	return "^(?:" + strings.Join(quoted, "|") + ")$"
+}
+
+// Find - finds specs of Ginkgo suite in execution package root by running ginkgo with --dry-run.
+func Find(manager execmanager.ExecutionManager, execution *config.Execution) (*model.Suite, error) {
+	reportFile, err := ioutil.TempFile("", "ginkgo-dry-run-*.json")
+	if err != nil {
+		return nil, err
+	}
+	_ = reportFile.Close()
+	defer func() { _ = os.Remove(reportFile.Name()) }()
+
+	args := append([]string{Binary(execution), "--dry-run", "--json-report", reportFile.Name()}, Args(execution)...)
+	if execution.Ginkgo != nil {
+		for _, focus := range execution.Ginkgo.Focus {
+			args = append(args, "--focus", focus)
+		}
+	}
+	args = append(args, ".")
+
+	output, err := utils.ExecRead(context.Background(), execution.PackageRoot, args)
+	manager.AddLog("ginkgo", "find-tests", strings.Join(args, " ")+"\n"+strings.Join(output, "\n"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find ginkgo specs in %v", execution.PackageRoot)
+	}
+
+	file, err := os.Open(reportFile.Name())
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+	reports, err := ReadReports(file)
+	if err != nil {
+		return nil, err
+	}
+	if len(reports) != 1 {
+		return nil, errors.Errorf("expected one ginkgo suite in %v, found: %v", execution.PackageRoot, len(reports))
+	}
+
+	suite := &model.Suite{Name: reports[0].SuiteDescription}
+	if suite.Name == "" {
+		root, _ := filepath.Abs(execution.PackageRoot)
+		suite.Name = filepath.Base(root)
+	}
+	found := map[string]bool{}
+	for _, spec := range reports[0].SpecReports {
+		if !spec.IsSpec() || spec.State == stateSkipped || spec.State == statePending {
+			continue
+		}
+		if name := spec.FullText(); !found[name] {
+			found[name] = true
+			suite.Tests = append(suite.Tests, name)
+		}
+	}
+	return suite, nil
+}
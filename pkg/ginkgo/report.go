@@ -0,0 +1,115 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ginkgo provides discovery of Ginkgo specs and conversion of Ginkgo JSON reports into test entries.
+package ginkgo
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// ReportPrefix - a prefix of output line ginkgo runner writes a path of JSON report of executed suite with.
+	ReportPrefix = "cloudtest-ginkgo-report: "
+	// specNodeType - a leaf node type of specs, other nodes are suite setup and teardown nodes.
+	specNodeType = "It"
+)
+
+// Report - a part of Ginkgo suite report stored with --json-report, cloudtest uses.
+type Report struct {
+	SuitePath        string
+	SuiteDescription string
+	SuiteSucceeded   bool
+	SpecReports      []*SpecReport
+}
+
+// SpecReport - a part of Ginkgo spec or suite node report.
+type SpecReport struct {
+	ContainerHierarchyTexts    []string
+	LeafNodeType               string
+	LeafNodeText               string
+	State                      string
+	StartTime                  time.Time
+	EndTime                    time.Time
+	RunTime                    time.Duration
+	Failure                    Failure
+	CapturedGinkgoWriterOutput string
+	CapturedStdOutErr          string
+}
+
+// Failure - a failure of Ginkgo spec or skip reason.
+type Failure struct {
+	Message        string
+	ForwardedPanic string
+	Location       struct {
+		FileName   string
+		LineNumber int
+	}
+}
+
+// IsSpec - returns true if report is a report of spec, not of suite node.
+func (r *SpecReport) IsSpec() bool {
+	return r.LeafNodeType == specNodeType
+}
+
+// FullText - returns a spec name, container texts and leaf text joined by space as Ginkgo matches focus with.
+func (r *SpecReport) FullText() string {
+	return strings.Join(append(append([]string{}, r.ContainerHierarchyTexts...), r.LeafNodeText), " ")
+}
+
+// ReadReports - reads Ginkgo JSON report.
+func ReadReports(reader io.Reader) ([]*Report, error) {
+	var reports []*Report
+	if err := json.NewDecoder(reader).Decode(&reports); err != nil {
+		return nil, errors.Wrap(err, "failed to parse ginkgo report")
+	}
+	return reports, nil
+}
+
+// ReadOutputReports - reads JSON report, path of which is written last with ReportPrefix into runner output, returns
+// nil if there is no one.
+func ReadOutputReports(reader io.Reader) ([]*Report, error) {
+	var fileName string
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if text := scanner.Text(); strings.HasPrefix(text, ReportPrefix) {
+			fileName = strings.TrimSpace(strings.TrimPrefix(text, ReportPrefix))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if fileName == "" {
+		return nil, nil
+	}
+	file, err := os.Open(fileName)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+	return ReadReports(file)
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ginkgo
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/networkservicemesh/cloudtest/pkg/execmanager"
+	"github.com/networkservicemesh/cloudtest/pkg/model"
+	"github.com/networkservicemesh/cloudtest/pkg/suites"
+	"github.com/networkservicemesh/cloudtest/pkg/suites/parse"
+	"github.com/networkservicemesh/cloudtest/pkg/suites/testentry"
+)
+
+const statePassed = "passed"
+
+// SplitSuite - returns test entries of specs of executed Ginkgo suite, results of specs are read from reports written
+// into output of every suite execution. Failed suite nodes, like BeforeSuite, are returned as suites.SetupSuite entry.
+func SplitSuite(
+	suite *model.TestEntry,
+	manager execmanager.ExecutionManager,
+	clusterTaskID string,
+) ([]*model.TestEntry, error) {
+	builders := make(map[string]*testentry.Builder)
+	for _, name := range suite.Suite.Tests {
+		builders[name] = testentry.NewBuilder(name, suite, manager, clusterTaskID)
+	}
+	setup := testentry.NewBuilder(suites.SetupSuite, suite, manager, clusterTaskID)
+	setupFailed := false
+
+	for _, execution := range suite.Executions {
+		reports, err := readOutputReports(execution.OutputFile)
+		if err != nil {
+			return nil, err
+		}
+		run := make(map[string]bool)
+		for _, report := range reports {
+			for _, spec := range report.SpecReports {
+				if !spec.IsSpec() {
+					if spec.State != statePassed {
+						setupFailed = true
+						if err = processSpec(setup, spec); err != nil {
+							return nil, err
+						}
+					}
+					continue
+				}
+				name := spec.FullText()
+				if builder, ok := builders[name]; ok && !run[name] {
+					run[name] = true
+					if err = processSpec(builder, spec); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+		for _, name := range suite.Suite.Tests {
+			if !run[name] {
+				if err = processNotRun(builders[name], suite, execution, reports != nil); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	var tests []*model.TestEntry
+	if setupFailed {
+		tests = append(tests, setup.Build())
+	}
+	for _, name := range suite.Suite.Tests {
+		tests = append(tests, builders[name].Build())
+	}
+	return tests, nil
+}
+
+// FailedSpecs - returns names of specs failed in the last execution of the suite, suites.SetupSuite is returned if
+// a suite node is failed or there is no report at all.
+func FailedSpecs(suite *model.TestEntry) ([]string, error) {
+	if len(suite.Executions) == 0 {
+		return nil, nil
+	}
+	reports, err := readOutputReports(suite.Executions[len(suite.Executions)-1].OutputFile)
+	if err != nil {
+		return nil, err
+	}
+	if reports == nil {
+		return []string{suites.SetupSuite}, nil
+	}
+	var failed []string
+	for _, report := range reports {
+		for _, spec := range report.SpecReports {
+			switch {
+			case spec.State == statePassed || spec.State == stateSkipped || spec.State == statePending:
+			case spec.IsSpec():
+				failed = append(failed, spec.FullText())
+			default:
+				failed = append(failed, suites.SetupSuite)
+			}
+		}
+	}
+	return failed, nil
+}
+
+func readOutputReports(fileName string) ([]*Report, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+	return ReadOutputReports(file)
+}
+
+func processSpec(builder *testentry.Builder, spec *SpecReport) error {
+	output := strings.Builder{}
+	_, _ = output.WriteString(spec.CapturedStdOutErr)
+	_, _ = output.WriteString(spec.CapturedGinkgoWriterOutput)
+	if spec.Failure.Message != "" {
+		_, _ = fmt.Fprintf(&output, "\n%s [%s]\n%s\n", spec.LeafNodeText, spec.State, spec.Failure.Message)
+		if spec.Failure.ForwardedPanic != "" {
+			_, _ = fmt.Fprintf(&output, "panic: %s\n", spec.Failure.ForwardedPanic)
+		}
+		if spec.Failure.Location.FileName != "" {
+			_, _ = fmt.Fprintf(&output, "%s:%d\n", spec.Failure.Location.FileName, spec.Failure.Location.LineNumber)
+		}
+	}
+
+	events := []*parse.TestEvent{
+		{Action: "run", Time: spec.StartTime},
+		{Action: "output", Time: spec.StartTime, Output: output.String()},
+	}
+	switch spec.State {
+	case statePassed:
+		events = append(events, &parse.TestEvent{Action: "pass", Time: spec.EndTime})
+	case stateSkipped, statePending:
+		message := spec.Failure.Message
+		if message == "" {
+			message = fmt.Sprintf("Spec is %s", spec.State)
+		}
+		events = append(events, &parse.TestEvent{Action: "skip", Time: spec.EndTime, Output: message})
+	default:
+		events = append(events, &parse.TestEvent{Action: "fail", Time: spec.EndTime})
+	}
+	for _, event := range events {
+		if err := event.Process(builder); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// processNotRun - processes spec missing in report, if there is no report at all ginkgo has not finished properly
+// and spec is failed.
+func processNotRun(builder *testentry.Builder, suite *model.TestEntry, execution model.TestEntryExecution, hasReport bool) error {
+	event := &parse.TestEvent{Time: suite.Started}
+	if err := builder.ProcessRunEvent(event); err != nil {
+		return err
+	}
+	if hasReport {
+		return builder.ProcessSkipEvent(&parse.TestEvent{Time: suite.Started, Output: "Spec is not executed"})
+	}
+	if err := builder.ProcessOutputEvent(&parse.TestEvent{
+		Output: fmt.Sprintf("Ginkgo report is not found, see suite output: %v\n", execution.OutputFile),
+	}); err != nil {
+		return err
+	}
+	return builder.ProcessFailEvent(event)
+}
@@ -0,0 +1,298 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// DefaultDiagnosticsLogLines - a default number of last log lines collected for every container.
+	DefaultDiagnosticsLogLines = 1000
+	// DefaultDiagnosticsMaxFileSize - a default limit of every diagnostics file.
+	DefaultDiagnosticsMaxFileSize = 1 << 20
+	// DefaultDiagnosticsMaxSize - a default limit of all diagnostics files of cluster.
+	DefaultDiagnosticsMaxSize = 10 << 20
+
+	truncatedMessage = "\n... truncated, diagnostics size limit is reached\n"
+)
+
+// DiagnosticsOptions - limits diagnostics collected from cluster.
+type DiagnosticsOptions struct {
+	Namespaces    []string // Namespaces to collect pods, logs and events from, all namespaces if empty.
+	LabelSelector string   // A label selector of pods, all pods if empty.
+	LogLines      int64    // A number of last log lines of every container.
+	MaxFileSize   int64    // A maximum size of every file in bytes.
+	MaxSize       int64    // A maximum size of all files in bytes, collecting is stopped if it is reached.
+}
+
+// podLogs - opens a log stream of pod, it is replaced in tests since fake clientset does not support logs.
+var podLogs = func(ctx context.Context, clientset kubernetes.Interface, pod *v1.Pod, options *v1.PodLogOptions) (io.ReadCloser, error) {
+	return clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, options).Stream(ctx)
+}
+
+// diagnosticsWriter - writes diagnostics files, limiting size of every file and of all files.
+type diagnosticsWriter struct {
+	dir         string
+	maxFileSize int64
+	maxSize     int64
+	left        int64
+}
+
+func (w *diagnosticsWriter) write(name string, content []byte) error {
+	if w.left <= 0 {
+		return errors.Errorf("diagnostics size limit of %v bytes is reached", w.maxSize)
+	}
+	limit := w.maxFileSize
+	if w.left < limit {
+		limit = w.left
+	}
+	if int64(len(content)) > limit {
+		content = append(content[:limit:limit], truncatedMessage...)
+	}
+	w.left -= int64(len(content))
+
+	fileName := filepath.Join(w.dir, name)
+	if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+		return errors.Wrapf(err, "failed to create diagnostics folder for %v", name)
+	}
+	return ioutil.WriteFile(fileName, content, 0644)
+}
+
+func (w *diagnosticsWriter) writeJSON(name string, value interface{}) error {
+	content, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal %v", name)
+	}
+	return w.write(name, content)
+}
+
+// CollectDiagnostics - writes node conditions, and pods, container logs and events of namespaces into dir:
+// nodes.txt, nodes.json, <namespace>/pods.txt, <namespace>/pods.json, <namespace>/events.txt and
+// <namespace>/logs/<pod>/<container>[.previous].log. JSON files contain full resource descriptions.
+func (u *Utils) CollectDiagnostics(ctx context.Context, options *DiagnosticsOptions, dir string) error {
+	w := &diagnosticsWriter{
+		dir:         dir,
+		maxFileSize: options.MaxFileSize,
+		maxSize:     options.MaxSize,
+	}
+	if w.maxFileSize <= 0 {
+		w.maxFileSize = DefaultDiagnosticsMaxFileSize
+	}
+	if w.maxSize <= 0 {
+		w.maxSize = DefaultDiagnosticsMaxSize
+	}
+	w.left = w.maxSize
+
+	nodes, err := u.clientset.CoreV1().Nodes().List(ctx, v12.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list nodes")
+	}
+	if err = w.write("nodes.txt", formatNodes(nodes.Items)); err != nil {
+		return err
+	}
+	if err = w.writeJSON("nodes.json", nodes.Items); err != nil {
+		return err
+	}
+
+	namespaces := options.Namespaces
+	if len(namespaces) == 0 {
+		list, err := u.clientset.CoreV1().Namespaces().List(ctx, v12.ListOptions{})
+		if err != nil {
+			return errors.Wrap(err, "failed to list namespaces")
+		}
+		for idx := range list.Items {
+			namespaces = append(namespaces, list.Items[idx].Name)
+		}
+	}
+	for _, namespace := range namespaces {
+		if err := u.collectNamespace(ctx, w, namespace, options); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *Utils) collectNamespace(ctx context.Context, w *diagnosticsWriter, namespace string, options *DiagnosticsOptions) error {
+	pods, err := u.clientset.CoreV1().Pods(namespace).List(ctx, v12.ListOptions{LabelSelector: options.LabelSelector})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list pods of namespace %v", namespace)
+	}
+	events, err := u.clientset.CoreV1().Events(namespace).List(ctx, v12.ListOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list events of namespace %v", namespace)
+	}
+	if len(pods.Items) == 0 && len(events.Items) == 0 {
+		return nil
+	}
+
+	podNames := map[string]bool{}
+	for idx := range pods.Items {
+		podNames[pods.Items[idx].Name] = true
+	}
+	var selected []v1.Event
+	for idx := range events.Items {
+		event := &events.Items[idx]
+		// Events of other pods are not collected if pods are selected by labels.
+		if options.LabelSelector != "" && (event.InvolvedObject.Kind != "Pod" || !podNames[event.InvolvedObject.Name]) {
+			continue
+		}
+		selected = append(selected, *event)
+	}
+
+	if err := w.write(filepath.Join(namespace, "pods.txt"), formatPods(pods.Items)); err != nil {
+		return err
+	}
+	if err := w.writeJSON(filepath.Join(namespace, "pods.json"), pods.Items); err != nil {
+		return err
+	}
+	if err := w.write(filepath.Join(namespace, "events.txt"), formatEvents(selected)); err != nil {
+		return err
+	}
+	for idx := range pods.Items {
+		if err := u.collectLogs(ctx, w, &pods.Items[idx], options); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectLogs - writes logs of all containers of pod, logs of previous instance are written for restarted containers.
+func (u *Utils) collectLogs(ctx context.Context, w *diagnosticsWriter, pod *v1.Pod, options *DiagnosticsOptions) error {
+	restarted := map[string]bool{}
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		restarted[status.Name] = status.RestartCount > 0
+	}
+	var containers []string
+	for idx := range pod.Spec.InitContainers {
+		containers = append(containers, pod.Spec.InitContainers[idx].Name)
+	}
+	for idx := range pod.Spec.Containers {
+		containers = append(containers, pod.Spec.Containers[idx].Name)
+	}
+
+	dir := filepath.Join(pod.Namespace, "logs", pod.Name)
+	for _, container := range containers {
+		if err := w.write(filepath.Join(dir, container+".log"), u.readLogs(ctx, pod, container, false, options, w.maxFileSize)); err != nil {
+			return err
+		}
+		if !restarted[container] {
+			continue
+		}
+		if err := w.write(filepath.Join(dir, container+".previous.log"), u.readLogs(ctx, pod, container, true, options, w.maxFileSize)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readLogs - returns last lines of container log, or an error message if log could not be read.
+func (u *Utils) readLogs(ctx context.Context, pod *v1.Pod, container string, previous bool, options *DiagnosticsOptions, limit int64) []byte {
+	tailLines := options.LogLines
+	if tailLines <= 0 {
+		tailLines = DefaultDiagnosticsLogLines
+	}
+	stream, err := podLogs(ctx, u.clientset, pod, &v1.PodLogOptions{
+		Container:  container,
+		Previous:   previous,
+		TailLines:  &tailLines,
+		LimitBytes: &limit,
+	})
+	if err != nil {
+		return []byte(fmt.Sprintf("failed to get logs of %v/%v: %v\n", pod.Name, container, err))
+	}
+	defer func() { _ = stream.Close() }()
+	content, err := ioutil.ReadAll(io.LimitReader(stream, limit))
+	if err != nil {
+		content = append(content, fmt.Sprintf("\nfailed to read logs of %v/%v: %v\n", pod.Name, container, err)...)
+	}
+	return content
+}
+
+func formatNodes(nodes []v1.Node) []byte {
+	result := &bytes.Buffer{}
+	table := tabwriter.NewWriter(result, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(table, "NAME\tCONDITION\tSTATUS\tREASON\tMESSAGE")
+	for idx := range nodes {
+		for _, condition := range nodes[idx].Status.Conditions {
+			_, _ = fmt.Fprintf(table, "%v\t%v\t%v\t%v\t%v\n", nodes[idx].Name, condition.Type, condition.Status,
+				condition.Reason, condition.Message)
+		}
+	}
+	_ = table.Flush()
+	return result.Bytes()
+}
+
+func formatPods(pods []v1.Pod) []byte {
+	result := &bytes.Buffer{}
+	table := tabwriter.NewWriter(result, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(table, "NAME\tREADY\tSTATUS\tRESTARTS\tNODE\tAGE")
+	for idx := range pods {
+		pod := &pods[idx]
+		ready := 0
+		restarts := int32(0)
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Ready {
+				ready++
+			}
+			restarts += status.RestartCount
+		}
+		status := string(pod.Status.Phase)
+		if pod.Status.Reason != "" {
+			status = pod.Status.Reason
+		}
+		_, _ = fmt.Fprintf(table, "%v\t%d/%d\t%v\t%d\t%v\t%v\n", pod.Name, ready, len(pod.Spec.Containers), status,
+			restarts, pod.Spec.NodeName, time.Since(pod.CreationTimestamp.Time).Round(time.Second))
+	}
+	_ = table.Flush()
+	return result.Bytes()
+}
+
+func formatEvents(events []v1.Event) []byte {
+	eventTime := func(event *v1.Event) time.Time {
+		if !event.LastTimestamp.IsZero() {
+			return event.LastTimestamp.Time
+		}
+		return event.EventTime.Time
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTime(&events[i]).Before(eventTime(&events[j]))
+	})
+	result := &strings.Builder{}
+	for idx := range events {
+		event := &events[idx]
+		_, _ = fmt.Fprintf(result, "%v %v %v %v/%v: %v\n", eventTime(event).Format(time.RFC3339), event.Type,
+			event.Reason, event.InvolvedObject.Kind, event.InvolvedObject.Name, strings.TrimSpace(event.Message))
+	}
+	return []byte(result.String())
+}
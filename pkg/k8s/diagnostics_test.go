@@ -0,0 +1,129 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newDiagnosticsUtils() *Utils {
+	podLogs = func(_ context.Context, _ kubernetes.Interface, pod *v1.Pod, options *v1.PodLogOptions) (io.ReadCloser, error) {
+		if options.Previous {
+			return ioutil.NopCloser(strings.NewReader(fmt.Sprintf("previous log of %v/%v\n", pod.Name, options.Container))), nil
+		}
+		return ioutil.NopCloser(strings.NewReader(fmt.Sprintf("log of %v/%v, %v lines\n", pod.Name, options.Container, *options.TailLines))), nil
+	}
+	return &Utils{clientset: fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: v12.ObjectMeta{Name: "nsm"}},
+		&v1.Node{
+			ObjectMeta: v12.ObjectMeta{Name: "node-1"},
+			Status: v1.NodeStatus{Conditions: []v1.NodeCondition{
+				{Type: v1.NodeReady, Status: v1.ConditionFalse, Reason: "KubeletNotReady", Message: "PLEG is not healthy"},
+			}},
+		},
+		&v1.Pod{
+			ObjectMeta: v12.ObjectMeta{Name: "nsmgr", Namespace: "nsm", Labels: map[string]string{"app": "nsmgr"}},
+			Spec: v1.PodSpec{
+				NodeName:   "node-1",
+				Containers: []v1.Container{{Name: "nsmgr"}, {Name: "forwarder"}},
+			},
+			Status: v1.PodStatus{
+				Phase: v1.PodRunning,
+				ContainerStatuses: []v1.ContainerStatus{
+					{Name: "nsmgr", Ready: true},
+					{Name: "forwarder", RestartCount: 3},
+				},
+			},
+		},
+		&v1.Pod{
+			ObjectMeta: v12.ObjectMeta{Name: "nse", Namespace: "nsm", Labels: map[string]string{"app": "nse"}},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "nse"}}},
+		},
+		&v1.Event{
+			ObjectMeta:     v12.ObjectMeta{Name: "nsmgr.1", Namespace: "nsm"},
+			InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: "nsmgr"},
+			Type:           "Warning",
+			Reason:         "BackOff",
+			Message:        "Back-off restarting failed container",
+		},
+		&v1.Event{
+			ObjectMeta:     v12.ObjectMeta{Name: "nse.1", Namespace: "nsm"},
+			InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: "nse"},
+			Type:           "Normal",
+			Reason:         "Pulled",
+		},
+	)}
+}
+
+func TestCollectDiagnostics(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "cloud-test-diagnostics")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	err = newDiagnosticsUtils().CollectDiagnostics(context.Background(), &DiagnosticsOptions{
+		Namespaces:    []string{"nsm"},
+		LabelSelector: "app=nsmgr",
+	}, dir)
+	require.NoError(t, err)
+
+	read := func(name string) string {
+		content, err := ioutil.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		return string(content)
+	}
+	require.Regexp(t, "node-1 +Ready +False +KubeletNotReady +PLEG is not healthy", read("nodes.txt"))
+	require.Contains(t, read("nodes.json"), `"name": "node-1"`)
+	require.Regexp(t, "nsmgr +1/2 +Running +3 +node-1", read("nsm/pods.txt"))
+	require.NotContains(t, read("nsm/pods.txt"), "nse")
+	require.Contains(t, read("nsm/events.txt"), "Warning BackOff Pod/nsmgr: Back-off restarting failed container")
+	require.NotContains(t, read("nsm/events.txt"), "Pulled")
+	require.Equal(t, "log of nsmgr/nsmgr, 1000 lines\n", read("nsm/logs/nsmgr/nsmgr.log"))
+	require.Equal(t, "log of nsmgr/forwarder, 1000 lines\n", read("nsm/logs/nsmgr/forwarder.log"))
+	require.Equal(t, "previous log of nsmgr/forwarder\n", read("nsm/logs/nsmgr/forwarder.previous.log"))
+	require.NoFileExists(t, filepath.Join(dir, "nsm/logs/nsmgr/nsmgr.previous.log"))
+	require.NoDirExists(t, filepath.Join(dir, "nsm/logs/nse"))
+}
+
+func TestCollectDiagnosticsSizeLimit(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "cloud-test-diagnostics")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	err = newDiagnosticsUtils().CollectDiagnostics(context.Background(), &DiagnosticsOptions{
+		MaxFileSize: 50,
+		MaxSize:     120,
+	}, dir)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "diagnostics size limit of 120 bytes is reached")
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, "nodes.txt"))
+	require.NoError(t, err)
+	require.Len(t, string(content), 50+len(truncatedMessage))
+}
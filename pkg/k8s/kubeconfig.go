@@ -0,0 +1,56 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// ExportKubeConfig - writes a kubeconfig with only one context of source kubeconfig into target file.
+// A first file of KUBECONFIG environment variable or ~/.kube/config is used if source is empty, current context of
+// source is used if context is empty. Relative paths of files kubeconfig refers to are resolved against its folder.
+func ExportKubeConfig(source, context, target string) error {
+	if source == "" {
+		source = clientcmd.RecommendedHomeFile
+		if paths := filepath.SplitList(os.Getenv(clientcmd.RecommendedConfigPathEnvVar)); len(paths) > 0 {
+			source = paths[0]
+		}
+	}
+	source, err := filepath.Abs(source)
+	if err != nil {
+		return err
+	}
+	config, err := clientcmd.LoadFromFile(source)
+	if err != nil {
+		return errors.Wrapf(err, "failed to load kubeconfig %v", source)
+	}
+	if context != "" {
+		config.CurrentContext = context
+	}
+	if err = clientcmd.ResolveLocalPaths(config); err != nil {
+		return errors.Wrapf(err, "failed to resolve paths of kubeconfig %v", source)
+	}
+	if err = api.MinifyConfig(config); err != nil {
+		return errors.Wrapf(err, "failed to export context %v of %v", config.CurrentContext, source)
+	}
+	return clientcmd.WriteToFile(*config, target)
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const sourceKubeConfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: first
+  cluster:
+    server: https://first:6443
+    certificate-authority: certs/ca.crt
+- name: second
+  cluster:
+    server: https://second:6443
+contexts:
+- name: first
+  context:
+    cluster: first
+    user: first-admin
+- name: second
+  context:
+    cluster: second
+    user: second-admin
+current-context: second
+users:
+- name: first-admin
+  user:
+    client-certificate: certs/admin.crt
+    client-key: /keys/admin.key
+- name: second-admin
+  user:
+    token: secret
+`
+
+func TestExportKubeConfig(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "cloud-test-kubeconfig")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	source := filepath.Join(tmpDir, "config")
+	require.NoError(t, ioutil.WriteFile(source, []byte(sourceKubeConfig), 0600))
+	target := filepath.Join(tmpDir, "exported")
+	require.NoError(t, ExportKubeConfig(source, "first", target))
+
+	config, err := clientcmd.LoadFromFile(target)
+	require.NoError(t, err)
+	require.Equal(t, "first", config.CurrentContext)
+	require.Len(t, config.Contexts, 1)
+	require.Len(t, config.Clusters, 1)
+	require.Len(t, config.AuthInfos, 1)
+	require.Equal(t, "https://first:6443", config.Clusters["first"].Server)
+	require.Equal(t, filepath.Join(tmpDir, "certs", "ca.crt"), config.Clusters["first"].CertificateAuthority)
+	require.Equal(t, filepath.Join(tmpDir, "certs", "admin.crt"), config.AuthInfos["first-admin"].ClientCertificate)
+	require.Equal(t, "/keys/admin.key", config.AuthInfos["first-admin"].ClientKey)
+
+	// Current context of source is exported by default.
+	require.NoError(t, ExportKubeConfig(source, "", target))
+	config, err = clientcmd.LoadFromFile(target)
+	require.NoError(t, err)
+	require.Equal(t, "second", config.CurrentContext)
+	require.Len(t, config.Clusters, 1)
+	require.Equal(t, "secret", config.AuthInfos["second-admin"].Token)
+
+	require.Error(t, ExportKubeConfig(source, "missing", target))
+}
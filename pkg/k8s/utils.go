@@ -29,7 +29,7 @@ import (
 // Utils - basic Kubernetes utils.
 type Utils struct {
 	config    *rest.Config
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
 // NewK8sUtils - Creates a new k8s utils with config file.
@@ -54,3 +54,18 @@ func (u *Utils) GetNodes() ([]v1.Node, error) {
 	}
 	return nodes.Items, nil
 }
+
+// CreateNamespace - creates a namespace with passed name.
+func (u *Utils) CreateNamespace(ctx context.Context, name string) error {
+	_, err := u.clientset.CoreV1().Namespaces().Create(ctx, &v1.Namespace{
+		ObjectMeta: v12.ObjectMeta{
+			Name: name,
+		},
+	}, v12.CreateOptions{})
+	return err
+}
+
+// DeleteNamespace - deletes a namespace with all its resources, it does not wait for namespace to be terminated.
+func (u *Utils) DeleteNamespace(ctx context.Context, name string) error {
+	return u.clientset.CoreV1().Namespaces().Delete(ctx, name, v12.DeleteOptions{})
+}
@@ -40,6 +40,29 @@ type ValidationFactory interface {
 	CreateValidator(config *config.ClusterProviderConfig, location string) (KubernetesValidator, error)
 }
 
+// NamespaceManager - creates and deletes namespaces used to isolate tests running on same cluster at once.
+type NamespaceManager interface {
+	CreateNamespace(ctx context.Context, name string) error
+	DeleteNamespace(ctx context.Context, name string) error
+}
+
+// NamespaceFactory - an optional extension of ValidationFactory to manage namespaces of cluster.
+type NamespaceFactory interface {
+	// CreateNamespaceManager - return instance of namespace manager for cluster config
+	CreateNamespaceManager(location string) (NamespaceManager, error)
+}
+
+// DiagnosticsCollector - collects diagnostics of cluster state on failure of test.
+type DiagnosticsCollector interface {
+	CollectDiagnostics(ctx context.Context, options *DiagnosticsOptions, dir string) error
+}
+
+// DiagnosticsFactory - an optional extension of ValidationFactory to collect diagnostics of cluster.
+type DiagnosticsFactory interface {
+	// CreateDiagnosticsCollector - return instance of diagnostics collector for cluster config
+	CreateDiagnosticsCollector(location string) (DiagnosticsCollector, error)
+}
+
 type k8sFactory struct {
 }
 
@@ -109,6 +132,14 @@ func (*k8sFactory) CreateValidator(config *config.ClusterProviderConfig, locatio
 	}, nil
 }
 
+func (*k8sFactory) CreateNamespaceManager(location string) (NamespaceManager, error) {
+	return NewK8sUtils(location)
+}
+
+func (*k8sFactory) CreateDiagnosticsCollector(location string) (DiagnosticsCollector, error) {
+	return NewK8sUtils(location)
+}
+
 // CreateFactory - creates a validation factory.
 func CreateFactory() ValidationFactory {
 	return &k8sFactory{}
@@ -19,6 +19,12 @@ package model
 import (
 	"context"
 	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -54,9 +60,13 @@ const (
 
 // TestEntryExecution - represent one test execution.
 type TestEntryExecution struct {
-	OutputFile string // Output file name
-	Retry      int    // Did we retry execution on this cluster.
-	Status     Status // Execution status
+	OutputFile  string        // Output file name
+	Retry       int           // Did we retry execution on this cluster.
+	Status      Status        // Execution status
+	Instances   []string      // Cluster instances test was executed on.
+	Started     time.Time     // A time test was started at.
+	Duration    time.Duration // A duration of execution.
+	Diagnostics []string      // Directories with Kubernetes diagnostics collected on failure.
 }
 
 // TestEntryKind - describes a testing way.
@@ -69,6 +79,12 @@ const (
 	ShellTestKind
 	// SuiteTestKind - go test suites
 	SuiteTestKind
+	// RegisteredTestKind - test executed by runner registered for kind of its execution.
+	RegisteredTestKind
+	// GinkgoTestKind - Ginkgo suite, specs are suite tests.
+	GinkgoTestKind
+	// ExternalTestKind - shell test, test cases are read from JUnit or TAP reports it writes.
+	ExternalTestKind
 )
 
 // TestEntry - represent one found test
@@ -84,77 +100,134 @@ type TestEntry struct {
 	Started    time.Time
 
 	RunScript string
+	Binary    string // A compiled test binary, tests are executed with go test if empty.
 
 	Kind   TestEntryKind
 	Status Status
+	Flaky  bool // Test had both failed and passed executions.
 	sync.Mutex
 	SkipMessage         string
 	ArtifactDirectories []string
+	FailureCategory     string // A category of failure assigned by failure rules.
+	FailureMessage      string // A description of failure assigned by failure rules.
 }
 
-// GetTestConfiguration - Return list of available tests by calling of gotest --list .* $root -tag "" and parsing of output.
-func GetTestConfiguration(manager execmanager.ExecutionManager, root string, source config.ExecutionSource) (map[string]*TestEntry, error) {
-	allTests, err1 := getTests(manager, root)
-	if len(source.Tags) > 0 {
-		tests, err := getTests(manager, root, source.Tags...)
+// GetBinaryTestConfiguration - Return list of tests available in compiled test binary by calling of binary -test.list .*
+// Binary is expected to be built with tags of execution, so only source tests are taken into account.
+func GetBinaryTestConfiguration(manager execmanager.ExecutionManager, binary, root string, source config.ExecutionSource) (map[string]*TestEntry, error) {
+	listCmd := []string{binary, "-test.list", ".*"}
+	result, err := utils.ExecRead(context.Background(), root, listCmd)
+	if err != nil {
+		logrus.Errorf("Error getting list of tests: %v\nOutput: %v\nCmdLine: %v", err, result, listCmd)
+		return nil, err
+	}
+	manager.AddLog("gotest", "find-tests", strings.Join(listCmd, " ")+"\n"+strings.Join(result, "\n"))
+
+	tagsStr := strings.Join(source.Tags, ",")
+	allTests := map[string]*TestEntry{}
+	for _, testLine := range result {
+		if testName := strings.TrimSpace(testLine); testName != "" {
+			allTests[testName] = &TestEntry{
+				Name:   testName,
+				Tags:   tagsStr,
+				Binary: binary,
+			}
+		}
+	}
+	if len(source.Tests) > 0 {
+		return selectTests(allTests, source.Tests)
+	}
+	return allTests, nil
+}
+
+// GetCompiledTestConfiguration - Return list of tests of package compiled with source tags into binary, so tests are
+// not compiled again to be listed. If tags are specified, only tests declared in files with build constraints are
+// returned.
+func GetCompiledTestConfiguration(manager execmanager.ExecutionManager, binary, root string, source config.ExecutionSource) (map[string]*TestEntry, error) {
+	if binary == "" {
+		// Package has no tests.
+		return map[string]*TestEntry{}, nil
+	}
+	if len(source.Tags) == 0 {
+		return GetBinaryTestConfiguration(manager, binary, root, source)
+	}
+	tests, err := GetBinaryTestConfiguration(manager, binary, root, config.ExecutionSource{Tags: source.Tags})
+	if err != nil {
+		return nil, err
+	}
+	untagged, err := findUntaggedTests(root)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range untagged {
+		delete(tests, name)
+	}
+	return tests, nil
+}
+
+// findUntaggedTests - returns names of tests declared in test files of package built without tags.
+func findUntaggedTests(root string) ([]string, error) {
+	pkg, err := build.Default.ImportDir(root, 0)
+	if err != nil {
+		if _, ok := err.(*build.NoGoError); ok {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to read package in %v", root)
+	}
+	var tests []string
+	fileSet := token.NewFileSet()
+	for _, fileName := range append(append([]string{}, pkg.TestGoFiles...), pkg.XTestGoFiles...) {
+		file, err := parser.ParseFile(fileSet, filepath.Join(pkg.Dir, fileName), nil, 0)
 		if err != nil {
 			return nil, err
 		}
-		for key := range allTests {
-			delete(tests, key)
-		}
-		return tests, nil
-	} else if len(source.Tests) > 0 {
-		result := map[string]*TestEntry{}
-		var err error
-		for _, n := range source.Tests {
-			t := allTests[n]
-			if t != nil {
-				result[n] = t
-			} else {
-				msg := fmt.Sprintf("test %v not found", n)
-				if err == nil {
-					err = errors.New(msg)
-				} else {
-					err = errors.Wrap(err, msg)
-				}
+		for _, decl := range file.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Recv == nil && strings.HasPrefix(funcDecl.Name.Name, "Test") {
+				tests = append(tests, funcDecl.Name.Name)
 			}
 		}
-		return result, err
 	}
-	return allTests, err1
+	return tests, nil
 }
 
-func getTests(manager execmanager.ExecutionManager, dir string, tags ...string) (map[string]*TestEntry, error) {
-	gotestCmd := []string{"go", "test", ".", "--list", ".*"}
-	tagsStr := strings.Join(tags, ",")
-	if len(tagsStr) != 0 {
-		gotestCmd = append(gotestCmd, "-tags", tagsStr)
+// BuildTestBinary - compiles tests of package in root into output file with 'go test -c', so tasks could run binary
+// directly. Returns an empty path if package has no tests.
+func BuildTestBinary(manager execmanager.ExecutionManager, root, output string, tags ...string) (string, error) {
+	buildCmd := []string{"go", "test", "-c", "-o", output}
+	if tagsStr := strings.Join(tags, ","); len(tagsStr) != 0 {
+		buildCmd = append(buildCmd, "-tags", tagsStr)
 	}
+	buildCmd = append(buildCmd, ".")
 
-	result, err := utils.ExecRead(context.Background(), dir, gotestCmd)
+	// Compilation errors are written into stderr, so they are stored in log as well.
+	cmd := exec.Command(buildCmd[0], buildCmd[1:]...)
+	cmd.Dir = root
+	result, err := cmd.CombinedOutput()
+	manager.AddLog("gotest", "build-tests", strings.Join(buildCmd, " ")+"\n"+string(result))
 	if err != nil {
-		logrus.Errorf("Error getting list of tests: %v\nOutput: %v\nCmdLine: %v", err, result, gotestCmd)
-		return nil, err
+		return "", errors.Wrapf(err, "failed to build tests in %v", root)
 	}
+	if !utils.FileExists(output) {
+		return "", nil
+	}
+	return output, nil
+}
 
-	testResult := map[string]*TestEntry{}
-
-	manager.AddLog("gotest", "find-tests", strings.Join(gotestCmd, " ")+"\n"+strings.Join(result, "\n"))
-	for _, testLine := range result {
-		if strings.ContainsAny(testLine, "\t") {
-			special := strings.Split(testLine, "\t")
-			if len(special) == 3 {
-				// This is special case.
-				continue
-			}
+func selectTests(allTests map[string]*TestEntry, names []string) (map[string]*TestEntry, error) {
+	result := map[string]*TestEntry{}
+	var err error
+	for _, n := range names {
+		t := allTests[n]
+		if t != nil {
+			result[n] = t
 		} else {
-			testName := strings.TrimSpace(testLine)
-			testResult[testName] = &TestEntry{
-				Name: testName,
-				Tags: tagsStr,
+			msg := fmt.Sprintf("test %v not found", n)
+			if err == nil {
+				err = errors.New(msg)
+			} else {
+				err = errors.Wrap(err, msg)
 			}
 		}
 	}
-	return testResult, nil
+	return result, err
 }
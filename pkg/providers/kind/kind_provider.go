@@ -0,0 +1,342 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kind provides a provider to start kind clusters
+package kind
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/execmanager"
+	"github.com/networkservicemesh/cloudtest/pkg/k8s"
+	"github.com/networkservicemesh/cloudtest/pkg/providers"
+	"github.com/networkservicemesh/cloudtest/pkg/shell"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+const (
+	// ClusterPrefix - a prefix of kind clusters created by cloudtest, leaked clusters with this prefix are deleted.
+	ClusterPrefix = "cloudtest-"
+
+	defaultBinary  = "kind"
+	kubeConfigFile = "config"
+	kindConfigFile = "kind.yaml"
+)
+
+type kindProvider struct {
+	root    string
+	indexes map[string]int
+	sync.Mutex
+	clusterNames map[string]bool
+}
+
+type kindInstance struct {
+	sync.Mutex
+	manager        execmanager.ExecutionManager
+	root           string
+	id             string
+	clusterName    string
+	factory        k8s.ValidationFactory
+	validator      k8s.KubernetesValidator
+	configLocation string
+	shellInterface shell.Manager
+	config         *config.ClusterProviderConfig
+	provider       *kindProvider
+	params         providers.InstanceOptions
+	started        bool
+}
+
+func (ki *kindInstance) GetID() string {
+	return ki.id
+}
+
+func (ki *kindInstance) CheckIsAlive() error {
+	ki.Lock()
+	defer ki.Unlock()
+	if ki.started {
+		return ki.validator.Validate()
+	}
+	return errors.New("cluster is not running")
+}
+
+func (ki *kindInstance) IsRunning() bool {
+	return ki.started
+}
+
+func (ki *kindInstance) GetClusterConfig() (string, error) {
+	if ki.started {
+		return ki.configLocation, nil
+	}
+	return "", errors.New("cluster is not started yet")
+}
+
+func (ki *kindInstance) GetRoot() string {
+	return ki.root
+}
+
+func (ki *kindInstance) Start(timeout time.Duration) (string, error) {
+	logrus.Infof("Starting cluster %s-%s", ki.config.Name, ki.id)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	utils.ClearFolder(ki.root, true)
+
+	// Process and prepare environment variables
+	if err := ki.shellInterface.ProcessEnvironment(ki.id, ki.config.Name, ki.root, ki.config.Env, nil); err != nil {
+		return "", err
+	}
+	ki.manager.AddLog(ki.id, "environment", ki.shellInterface.PrintEnv(ki.shellInterface.GetProcessedEnv()))
+
+	kindConfig := kindClusterConfig(ki.config)
+	createCmd, err := ki.createCommand(kindConfig)
+	if err != nil {
+		return "", err
+	}
+
+	// A new cluster name is used for every start, since previous cluster could be still deleting.
+	ki.clusterName = ki.provider.newClusterName()
+	ki.configLocation = path.Join(ki.root, kubeConfigFile)
+	createCmd = fmt.Sprintf("%s --name %s --kubeconfig %s", createCmd, ki.clusterName, ki.configLocation)
+
+	if fileName, err := ki.shellInterface.RunCmd(ctx, "start", []string{createCmd}, nil); err != nil {
+		return fileName, err
+	}
+
+	var loadScript []string
+	for _, image := range kindConfig.Images {
+		loadScript = append(loadScript, fmt.Sprintf("%s load docker-image %s --name %s", binary(kindConfig), image, ki.clusterName))
+	}
+	if len(loadScript) > 0 {
+		if fileName, err := ki.shellInterface.RunCmd(ctx, "load", loadScript, nil); err != nil {
+			return fileName, err
+		}
+	}
+
+	ki.Lock()
+	ki.validator, err = ki.factory.CreateValidator(ki.config, ki.configLocation)
+	ki.Unlock()
+	if err != nil {
+		logrus.Errorf("Failed to start validator %v", err)
+		return "", err
+	}
+
+	st := time.Now()
+	if err = ki.validator.WaitValid(ctx); err != nil {
+		logrus.Errorf("Failed to wait for required number of nodes: %v", err)
+		return "", err
+	}
+	logrus.Infof("Waiting for desired number of nodes complete %s-%s %v", ki.config.Name, ki.id, time.Since(st))
+
+	ki.started = true
+
+	return "", nil
+}
+
+// createCommand - returns kind create command without cluster name and kubeconfig location.
+func (ki *kindInstance) createCommand(kindConfig *config.KindConfig) (string, error) {
+	cmd := fmt.Sprintf("%s create cluster", binary(kindConfig))
+	configFile := kindConfig.Config
+	if configFile == "" && kindConfig.ConfigData != "" {
+		configFile = path.Join(ki.root, kindConfigFile)
+		if err := ioutil.WriteFile(configFile, []byte(kindConfig.ConfigData), 0644); err != nil {
+			return "", errors.Wrapf(err, "failed to write kind config %v", configFile)
+		}
+	}
+	if configFile != "" {
+		cmd += " --config " + configFile
+	}
+	if kindConfig.NodeImage != "" {
+		cmd += " --image " + kindConfig.NodeImage
+	}
+	if kindConfig.Wait > 0 {
+		cmd += fmt.Sprintf(" --wait %ds", kindConfig.Wait)
+	}
+	return cmd, nil
+}
+
+func (ki *kindInstance) Destroy(timeout time.Duration) error {
+	logrus.Infof("Destroying cluster  %s", ki.id)
+
+	ki.started = false
+	if ki.clusterName == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	deleteCmd := fmt.Sprintf("%s delete cluster --name %s", binary(kindClusterConfig(ki.config)), ki.clusterName)
+	attempts := ki.config.RetryCount
+	for {
+		_, err := ki.shellInterface.RunCmd(ctx, fmt.Sprintf("destroy-%d", ki.config.RetryCount-attempts), []string{deleteCmd}, nil)
+		if err == nil || attempts == 0 {
+			if err == nil {
+				ki.provider.releaseClusterName(ki.clusterName)
+			}
+			return err
+		}
+		attempts--
+	}
+}
+
+func (p *kindProvider) getProviderID(provider string) string {
+	val, ok := p.indexes[provider]
+	if ok {
+		val++
+	} else {
+		val = 1
+	}
+	p.indexes[provider] = val
+	return fmt.Sprintf("%d", val)
+}
+
+// newClusterName - generates an unique cluster name and remembers it, so cleanup will not delete it.
+func (p *kindProvider) newClusterName() string {
+	p.Lock()
+	defer p.Unlock()
+	name := ClusterPrefix + utils.NewRandomStr(10)
+	p.clusterNames[name] = true
+	return name
+}
+
+func (p *kindProvider) releaseClusterName(name string) {
+	p.Lock()
+	defer p.Unlock()
+	delete(p.clusterNames, name)
+}
+
+func (p *kindProvider) isOwnCluster(name string) bool {
+	p.Lock()
+	defer p.Unlock()
+	return p.clusterNames[name]
+}
+
+func (p *kindProvider) CreateCluster(config *config.ClusterProviderConfig, factory k8s.ValidationFactory,
+	manager execmanager.ExecutionManager,
+	instanceOptions providers.InstanceOptions) (providers.ClusterInstance, error) {
+	err := p.ValidateConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	p.Lock()
+	defer p.Unlock()
+	id := fmt.Sprintf("%s-%s", config.Name, p.getProviderID(config.Name))
+
+	clusterInstance := &kindInstance{
+		manager:        manager,
+		provider:       p,
+		root:           path.Join(p.root, id),
+		id:             id,
+		config:         config,
+		factory:        factory,
+		shellInterface: shell.NewManager(manager, id, config, instanceOptions),
+		params:         instanceOptions,
+	}
+
+	return clusterInstance, nil
+}
+
+// CleanupClusters - deletes leaked kind clusters created by previous runs if it is enabled, clusters of current run
+// are kept. Clusters of other cloudtest processes could not be distinguished, so cleanup is disabled by default.
+func (p *kindProvider) CleanupClusters(ctx context.Context, config *config.ClusterProviderConfig,
+	manager execmanager.ExecutionManager, instanceOptions providers.InstanceOptions) {
+	kindConfig := kindClusterConfig(config)
+	if !kindConfig.CleanupLeaked {
+		return
+	}
+	clusterID := fmt.Sprintf("%s-cleanup", config.Name)
+	kindBinary := binary(kindConfig)
+
+	logrus.Infof("Starting cleaning up clusters for %s", config.Name)
+	shellInterface := shell.NewManager(manager, clusterID, config, instanceOptions)
+	if err := shellInterface.ProcessEnvironment(clusterID, config.Name, p.root, config.Env, nil); err != nil {
+		logrus.Warnf("Failed to process environment for cluster %s: %v", config.Name, err)
+		return
+	}
+
+	output, err := shellInterface.RunRead(ctx, "cleanup-list", []string{kindBinary + " get clusters"}, nil)
+	if err != nil {
+		logrus.Warnf("Failed to list kind clusters for %s: %v", config.Name, err)
+		return
+	}
+	for _, name := range strings.Split(output, "\n") {
+		name = strings.TrimSpace(name)
+		if !strings.HasPrefix(name, ClusterPrefix) || p.isOwnCluster(name) {
+			continue
+		}
+		logrus.Infof("Deleting leaked kind cluster %s", name)
+		deleteCmd := fmt.Sprintf("%s delete cluster --name %s", kindBinary, name)
+		if _, err := shellInterface.RunCmd(ctx, "cleanup-"+name, []string{deleteCmd}, nil); err != nil {
+			logrus.Warnf("Cleanup of kind cluster %s finished with error: %v", name, err)
+		}
+	}
+}
+
+func (p *kindProvider) ValidateConfig(config *config.ClusterProviderConfig) error {
+	kindConfig := kindClusterConfig(config)
+	if kindConfig.Config != "" && kindConfig.ConfigData != "" {
+		return errors.New("only one of kind config location and config data could be specified")
+	}
+	if kindConfig.Config != "" && !utils.FileExists(kindConfig.Config) {
+		return errors.Errorf("kind config %v is not found", kindConfig.Config)
+	}
+	if _, err := exec.LookPath(binary(kindConfig)); err != nil {
+		return errors.Wrapf(err, "kind binary %v is not found", binary(kindConfig))
+	}
+	for _, envVar := range config.EnvCheck {
+		if os.Getenv(envVar) == "" {
+			return errors.Errorf("environment variable are not specified %s Required variables: %v", envVar, config.EnvCheck)
+		}
+	}
+	return nil
+}
+
+func kindClusterConfig(providerConfig *config.ClusterProviderConfig) *config.KindConfig {
+	if providerConfig.KindCluster == nil {
+		return &config.KindConfig{}
+	}
+	return providerConfig.KindCluster
+}
+
+func binary(kindConfig *config.KindConfig) string {
+	if kindConfig.Binary == "" {
+		return defaultBinary
+	}
+	return kindConfig.Binary
+}
+
+// NewKindClusterProvider - Creates new kind provider
+func NewKindClusterProvider(root string) providers.ClusterProvider {
+	utils.ClearFolder(root, true)
+	return &kindProvider{
+		root:         root,
+		indexes:      map[string]int{},
+		clusterNames: map[string]bool{},
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+)
+
+// client - runs plugin operations.
+type client struct {
+	binary string
+	args   []string
+	env    []string
+}
+
+func newClient(cfg *config.ClusterProviderConfig, env []string) *client {
+	return &client{
+		binary: cfg.Exec.Binary,
+		args:   cfg.Exec.Args,
+		env:    env,
+	}
+}
+
+func providerConfig(cfg *config.ClusterProviderConfig) *ProviderConfig {
+	return &ProviderConfig{
+		Name:       cfg.Name,
+		NodeCount:  cfg.NodeCount,
+		Instances:  cfg.Instances,
+		Parameters: cfg.Parameters,
+	}
+}
+
+// syncWriter - a writer could be used by stdout and stderr processing at once.
+type syncWriter struct {
+	sync.Mutex
+	out io.Writer
+}
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.Lock()
+	defer w.Unlock()
+	return w.out.Write(p)
+}
+
+// call - executes plugin operation, log messages and stderr of plugin are written into out.
+func (c *client) call(ctx context.Context, request *Request, out io.Writer) (*Result, error) {
+	request.Version = ProtocolVersion
+	if deadline, ok := ctx.Deadline(); ok {
+		request.Timeout = time.Until(deadline).Seconds()
+	}
+	input, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	writer := &syncWriter{out: out}
+	args := append(append([]string{}, c.args...), request.Operation)
+	cmd := exec.CommandContext(ctx, c.binary, args...)
+	cmd.Env = append(os.Environ(), c.env...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stderr = writer
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err = cmd.Start(); err != nil {
+		return nil, errors.Wrapf(err, "failed to start plugin %v", c.binary)
+	}
+
+	var result *Result
+	var pluginErr error
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		msg := &Message{}
+		if err := json.Unmarshal(line, msg); err != nil {
+			_, _ = writer.Write(append(line, '\n'))
+			continue
+		}
+		if msg.Log != "" {
+			_, _ = fmt.Fprintln(writer, msg.Log)
+		}
+		if msg.Result == nil && msg.Error == "" {
+			continue
+		}
+		if msg.Version != ProtocolVersion {
+			pluginErr = errors.Errorf("unsupported protocol version %v, expected %v", msg.Version, ProtocolVersion)
+			continue
+		}
+		if msg.Error != "" {
+			pluginErr = errors.New(msg.Error)
+			continue
+		}
+		result = msg.Result
+	}
+	if err = scanner.Err(); err != nil {
+		// Plugin should not be blocked on writing into a full pipe, so the rest of output is drained.
+		_, _ = io.Copy(ioutil.Discard, stdout)
+		pluginErr = errors.Wrapf(err, "failed to read output of plugin %v %v", c.binary, request.Operation)
+	}
+
+	if err = cmd.Wait(); err != nil && pluginErr == nil {
+		pluginErr = errors.Wrapf(err, "plugin %v %v failed", c.binary, request.Operation)
+	}
+	if pluginErr != nil {
+		_, _ = fmt.Fprintf(writer, "error: %v\n", pluginErr)
+		return nil, pluginErr
+	}
+	if result == nil {
+		return nil, errors.Errorf("plugin %v %v returned no result", c.binary, request.Operation)
+	}
+	return result, nil
+}
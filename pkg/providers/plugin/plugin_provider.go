@@ -0,0 +1,351 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package plugin provides a provider delegating operations with clusters to external plugin binary
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/execmanager"
+	"github.com/networkservicemesh/cloudtest/pkg/k8s"
+	"github.com/networkservicemesh/cloudtest/pkg/providers"
+	"github.com/networkservicemesh/cloudtest/pkg/shell"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+const checkAliveTimeout = time.Minute
+
+type pluginProvider struct {
+	root    string
+	indexes map[string]int
+	sync.Mutex
+}
+
+type pluginInstance struct {
+	sync.Mutex
+	manager        execmanager.ExecutionManager
+	root           string
+	id             string
+	factory        k8s.ValidationFactory
+	validator      k8s.KubernetesValidator
+	configLocation string
+	client         *client
+	config         *config.ClusterProviderConfig
+	provider       *pluginProvider
+	params         providers.InstanceOptions
+	state          json.RawMessage
+	created        bool
+	started        bool
+}
+
+func (pi *pluginInstance) GetID() string {
+	return pi.id
+}
+
+func (pi *pluginInstance) CheckIsAlive() error {
+	pi.Lock()
+	defer pi.Unlock()
+	if !pi.started {
+		return errors.New("cluster is not running")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), checkAliveTimeout)
+	defer cancel()
+	// Plugin output is stored only on failure, since check is performed periodically.
+	out := &bytes.Buffer{}
+	if _, err := pi.client.call(ctx, pi.request(OperationCheckAlive), out); err != nil {
+		pi.manager.AddLog(pi.id, OperationCheckAlive, out.String())
+		return err
+	}
+	return pi.validator.Validate()
+}
+
+func (pi *pluginInstance) IsRunning() bool {
+	return pi.started
+}
+
+func (pi *pluginInstance) GetClusterConfig() (string, error) {
+	if pi.started {
+		return pi.configLocation, nil
+	}
+	return "", errors.New("cluster is not started yet")
+}
+
+func (pi *pluginInstance) GetRoot() string {
+	return pi.root
+}
+
+func (pi *pluginInstance) request(operation string) *Request {
+	return &Request{
+		Operation: operation,
+		Provider:  providerConfig(pi.config),
+		ID:        pi.id,
+		Root:      pi.root,
+		State:     pi.state,
+	}
+}
+
+// run - executes plugin operation with instance and streams its output into execution manager log file.
+func (pi *pluginInstance) run(ctx context.Context, operation string) (*Result, string, error) {
+	fileName, file, err := pi.manager.OpenFile(pi.id, operation)
+	if err != nil {
+		return nil, fileName, err
+	}
+	defer func() { _ = file.Close() }()
+
+	logrus.Infof("%s: %s => plugin %s", operation, pi.id, pi.client.binary)
+	result, err := pi.client.call(ctx, pi.request(operation), file)
+	if err != nil {
+		return nil, fileName, err
+	}
+	if result.State != nil {
+		pi.state = result.State
+	}
+	return result, fileName, nil
+}
+
+// create - validates plugin configuration and creates cluster instance in plugin on first start, plugin is not
+// called on instance creation, so planning does not execute plugins.
+func (pi *pluginInstance) create(ctx context.Context) (string, error) {
+	if pi.created {
+		return "", nil
+	}
+	env, err := processEnvironment(pi.manager, pi.id, pi.root, pi.config, pi.params)
+	if err != nil {
+		return "", err
+	}
+	if err = pi.provider.validatePlugin(pi.config, env); err != nil {
+		return "", err
+	}
+	pi.client = newClient(pi.config, env)
+	if _, fileName, err := pi.run(ctx, OperationCreate); err != nil {
+		return fileName, errors.Wrapf(err, "failed to create cluster instance %v", pi.id)
+	}
+	pi.created = true
+	return "", nil
+}
+
+func (pi *pluginInstance) Start(timeout time.Duration) (string, error) {
+	logrus.Infof("Starting cluster %s-%s", pi.config.Name, pi.id)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	utils.ClearFolder(pi.root, true)
+
+	if fileName, err := pi.create(ctx); err != nil {
+		return fileName, err
+	}
+	result, fileName, err := pi.run(ctx, OperationStart)
+	if err != nil {
+		return fileName, err
+	}
+	pi.configLocation = result.KubeConfig
+	if pi.configLocation == "" {
+		if result, fileName, err = pi.run(ctx, OperationGetConfig); err != nil {
+			return fileName, err
+		}
+		pi.configLocation = result.KubeConfig
+	}
+	if pi.configLocation == "" {
+		return "", errors.Errorf("plugin %v returned no kubeconfig for %v", pi.client.binary, pi.id)
+	}
+
+	pi.Lock()
+	pi.validator, err = pi.factory.CreateValidator(pi.config, pi.configLocation)
+	pi.Unlock()
+	if err != nil {
+		logrus.Errorf("Failed to start validator %v", err)
+		return "", err
+	}
+
+	st := time.Now()
+	if err = pi.validator.WaitValid(ctx); err != nil {
+		logrus.Errorf("Failed to wait for required number of nodes: %v", err)
+		return "", err
+	}
+	logrus.Infof("Waiting for desired number of nodes complete %s-%s %v", pi.config.Name, pi.id, time.Since(st))
+
+	pi.started = true
+
+	return "", nil
+}
+
+func (pi *pluginInstance) Destroy(timeout time.Duration) error {
+	logrus.Infof("Destroying cluster  %s", pi.id)
+
+	pi.started = false
+	if !pi.created {
+		// Plugin knows nothing about instance.
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	attempts := pi.config.RetryCount
+	for {
+		_, _, err := pi.run(ctx, OperationDestroy)
+		if err == nil {
+			// Instance is removed from plugin, so it is created again on restart.
+			pi.created = false
+			pi.state = nil
+			return nil
+		}
+		if attempts == 0 {
+			return err
+		}
+		attempts--
+	}
+}
+
+func (p *pluginProvider) getProviderID(provider string) string {
+	val, ok := p.indexes[provider]
+	if ok {
+		val++
+	} else {
+		val = 1
+	}
+	p.indexes[provider] = val
+	return fmt.Sprintf("%d", val)
+}
+
+// processEnvironment - returns provider environment variables with substituted values.
+func processEnvironment(manager execmanager.ExecutionManager, id, root string, cfg *config.ClusterProviderConfig,
+	instanceOptions providers.InstanceOptions) ([]string, error) {
+	shellInterface := shell.NewManager(manager, id, cfg, instanceOptions)
+	if err := shellInterface.ProcessEnvironment(id, cfg.Name, root, cfg.Env, nil); err != nil {
+		return nil, err
+	}
+	if manager != nil {
+		manager.AddLog(id, "environment", shellInterface.PrintEnv(shellInterface.GetProcessedEnv()))
+	}
+	return shellInterface.GetProcessedEnv(), nil
+}
+
+func (p *pluginProvider) CreateCluster(config *config.ClusterProviderConfig, factory k8s.ValidationFactory,
+	manager execmanager.ExecutionManager,
+	instanceOptions providers.InstanceOptions) (providers.ClusterInstance, error) {
+	if err := checkConfig(config); err != nil {
+		return nil, err
+	}
+	p.Lock()
+	id := fmt.Sprintf("%s-%s", config.Name, p.getProviderID(config.Name))
+	p.Unlock()
+
+	return &pluginInstance{
+		manager:  manager,
+		root:     path.Join(p.root, id),
+		id:       id,
+		config:   config,
+		factory:  factory,
+		provider: p,
+		params:   instanceOptions,
+	}, nil
+}
+
+// CleanupClusters - asks plugin to remove leaked clusters.
+func (p *pluginProvider) CleanupClusters(ctx context.Context, config *config.ClusterProviderConfig,
+	manager execmanager.ExecutionManager, instanceOptions providers.InstanceOptions) {
+	clusterID := fmt.Sprintf("%s-cleanup", config.Name)
+
+	logrus.Infof("Starting cleaning up clusters for %s", config.Name)
+	env, err := processEnvironment(manager, clusterID, p.root, config, instanceOptions)
+	if err != nil {
+		logrus.Warnf("Failed to process environment for cluster %s: %v", config.Name, err)
+		return
+	}
+	_, file, err := manager.OpenFile(clusterID, OperationCleanup)
+	if err != nil {
+		logrus.Warnf("Cleanup command for cluster %s finished with error: %v", config.Name, err)
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	request := &Request{
+		Operation: OperationCleanup,
+		Provider:  providerConfig(config),
+		Root:      p.root,
+	}
+	if _, err := newClient(config, env).call(ctx, request, file); err != nil {
+		logrus.Warnf("Cleanup command for cluster %s finished with error: %v", config.Name, err)
+	}
+}
+
+// checkConfig - checks configuration without calling plugin.
+func checkConfig(config *config.ClusterProviderConfig) error {
+	if config.Exec == nil || config.Exec.Binary == "" {
+		return errors.New("plugin binary is not specified")
+	}
+	if _, err := exec.LookPath(config.Exec.Binary); err != nil {
+		return errors.Wrapf(err, "plugin binary %v is not found", config.Exec.Binary)
+	}
+	for _, envVar := range config.EnvCheck {
+		if os.Getenv(envVar) == "" {
+			return errors.Errorf("environment variable are not specified %s Required variables: %v", envVar, config.EnvCheck)
+		}
+	}
+	return nil
+}
+
+func (p *pluginProvider) ValidateConfig(config *config.ClusterProviderConfig) error {
+	if err := checkConfig(config); err != nil {
+		return err
+	}
+	id := fmt.Sprintf("%s-validate", config.Name)
+	env, err := processEnvironment(nil, id, path.Join(p.root, id), config, providers.InstanceOptions{})
+	if err != nil {
+		return err
+	}
+	return p.validatePlugin(config, env)
+}
+
+// validatePlugin - asks plugin to validate configuration with processed environment.
+func (p *pluginProvider) validatePlugin(config *config.ClusterProviderConfig, env []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), checkAliveTimeout)
+	defer cancel()
+	out := &strings.Builder{}
+	request := &Request{
+		Operation: OperationValidate,
+		Provider:  providerConfig(config),
+	}
+	if _, err := newClient(config, env).call(ctx, request, out); err != nil {
+		logrus.Errorf("Plugin %v validation output:\n%v", config.Exec.Binary, out.String())
+		return errors.Wrap(err, "plugin validation failed")
+	}
+	return nil
+}
+
+// NewPluginClusterProvider - Creates new provider delegating operations to external plugins
+func NewPluginClusterProvider(root string) providers.ClusterProvider {
+	utils.ClearFolder(root, true)
+	return &pluginProvider{
+		root:    root,
+		indexes: map[string]int{},
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import "encoding/json"
+
+// ProtocolVersion - a version of protocol between cloudtest and provider plugins.
+const ProtocolVersion = 1
+
+// Operations supported by plugins, an operation name is passed as last argument of plugin and in request.
+const (
+	OperationValidate   = "validate"
+	OperationCreate     = "create"
+	OperationStart      = "start"
+	OperationGetConfig  = "get-config"
+	OperationCheckAlive = "check-alive"
+	OperationDestroy    = "destroy"
+	OperationCleanup    = "cleanup"
+)
+
+// ProviderConfig - a provider configuration passed to plugin.
+type ProviderConfig struct {
+	Name       string            `json:"name"`
+	NodeCount  int               `json:"node-count"`
+	Instances  int               `json:"instances"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// Request - a request written into plugin stdin, every operation is executed by a new plugin process.
+type Request struct {
+	Version   int             `json:"version"`
+	Operation string          `json:"operation"`
+	Provider  *ProviderConfig `json:"provider"`
+	ID        string          `json:"id,omitempty"`      // A cluster instance id, empty for validate and cleanup.
+	Root      string          `json:"root,omitempty"`    // A folder plugin could use to store instance files, like kubeconfig.
+	Timeout   float64         `json:"timeout,omitempty"` // Operation timeout in seconds, plugin process is killed after it.
+	State     json.RawMessage `json:"state,omitempty"`   // An instance state returned by previous operation.
+}
+
+// Result - a result of operation.
+type Result struct {
+	State      json.RawMessage `json:"state,omitempty"`      // An instance state, it is passed to next operations with instance.
+	KubeConfig string          `json:"kubeconfig,omitempty"` // A kubeconfig location, returned by start and get-config.
+}
+
+// Message - a line of JSON written by plugin into stdout. Log messages are streamed into operation log file, last
+// message should contain either result or error. Lines could not be parsed are treated as log lines, stderr is
+// written into operation log file as is.
+type Message struct {
+	Version int     `json:"version"`
+	Log     string  `json:"log,omitempty"`
+	Result  *Result `json:"result,omitempty"`
+	Error   string  `json:"error,omitempty"`
+}
@@ -55,6 +55,12 @@ type ClusterInstance interface {
 	GetID() string
 }
 
+// ResettableInstance - an optional extension of ClusterInstance, instance is reset after every test completed on it.
+type ResettableInstance interface {
+	// Reset - brings cluster back into initial state, an error makes cluster instance to be restarted.
+	Reset(timeout time.Duration) error
+}
+
 // ClusterProvider - provides operations with clusters
 type ClusterProvider interface {
 	// CreateCluster - Create a cluster based on parameters
@@ -0,0 +1,68 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package providers
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+var registry = struct {
+	sync.RWMutex
+	factories map[string]ClusterProviderFunction
+}{
+	factories: map[string]ClusterProviderFunction{},
+}
+
+// Register - makes cluster provider available for providers of passed kind in configuration.
+// It panics if kind is empty, factory is nil or provider of the kind is already registered.
+func Register(kind string, factory ClusterProviderFunction) {
+	if kind == "" || factory == nil {
+		panic(errors.Errorf("invalid registration of cluster provider kind %q", kind))
+	}
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.factories[kind]; ok {
+		panic(errors.Errorf("cluster provider kind %q is already registered", kind))
+	}
+	registry.factories[kind] = factory
+}
+
+// Registered - returns a copy of registered cluster provider factories by kind.
+func Registered() map[string]ClusterProviderFunction {
+	registry.RLock()
+	defer registry.RUnlock()
+	result := make(map[string]ClusterProviderFunction, len(registry.factories))
+	for kind, factory := range registry.factories {
+		result[kind] = factory
+	}
+	return result
+}
+
+// Kinds - returns sorted kinds of registered cluster providers.
+func Kinds() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+	var result []string
+	for kind := range registry.factories {
+		result = append(result, kind)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package static provides a provider to use existing clusters
+package static
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/execmanager"
+	"github.com/networkservicemesh/cloudtest/pkg/k8s"
+	"github.com/networkservicemesh/cloudtest/pkg/providers"
+	"github.com/networkservicemesh/cloudtest/pkg/shell"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+const (
+	resetScript    = "reset"
+	kubeConfigFile = "config"
+)
+
+type staticProvider struct {
+	root    string
+	indexes map[string]int
+	sync.Mutex
+}
+
+// staticInstance - an instance of existing cluster, it is never created or deleted by cloudtest.
+type staticInstance struct {
+	sync.Mutex
+	manager        execmanager.ExecutionManager
+	root           string
+	id             string
+	cluster        *config.StaticClusterConfig
+	resetScript    []string
+	factory        k8s.ValidationFactory
+	validator      k8s.KubernetesValidator
+	configLocation string
+	shellInterface shell.Manager
+	config         *config.ClusterProviderConfig
+	started        bool
+}
+
+func (si *staticInstance) GetID() string {
+	return si.id
+}
+
+func (si *staticInstance) CheckIsAlive() error {
+	si.Lock()
+	defer si.Unlock()
+	if si.started {
+		return si.validator.Validate()
+	}
+	return errors.New("cluster is not running")
+}
+
+func (si *staticInstance) IsRunning() bool {
+	return si.started
+}
+
+func (si *staticInstance) GetClusterConfig() (string, error) {
+	if si.started {
+		return si.configLocation, nil
+	}
+	return "", errors.New("cluster is not started yet")
+}
+
+func (si *staticInstance) GetRoot() string {
+	return si.root
+}
+
+// Start - exports kubeconfig of cluster into instance root and waits for cluster to be valid.
+func (si *staticInstance) Start(timeout time.Duration) (string, error) {
+	logrus.Infof("Connecting to existing cluster %s", si.id)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	utils.ClearFolder(si.root, true)
+
+	if err := si.shellInterface.ProcessEnvironment(si.id, si.config.Name, si.root, si.config.Env, nil); err != nil {
+		return "", err
+	}
+	si.manager.AddLog(si.id, "environment", si.shellInterface.PrintEnv(si.shellInterface.GetProcessedEnv()))
+
+	si.configLocation = path.Join(si.root, kubeConfigFile)
+	if err := k8s.ExportKubeConfig(si.cluster.KubeConfig, si.cluster.Context, si.configLocation); err != nil {
+		return "", errors.Wrapf(err, "failed to export kubeconfig of cluster %v", si.id)
+	}
+
+	var err error
+	si.Lock()
+	si.validator, err = si.factory.CreateValidator(si.config, si.configLocation)
+	si.Unlock()
+	if err != nil {
+		logrus.Errorf("Failed to start validator %v", err)
+		return "", err
+	}
+
+	if err = si.validator.WaitValid(ctx); err != nil {
+		logrus.Errorf("Failed to wait for required number of nodes: %v", err)
+		return "", err
+	}
+
+	si.started = true
+
+	return "", nil
+}
+
+// Reset - runs reset script against cluster.
+func (si *staticInstance) Reset(timeout time.Duration) error {
+	if len(si.resetScript) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	_, err := si.shellInterface.RunCmd(ctx, "reset", si.resetScript, []string{"KUBECONFIG=" + si.configLocation})
+	return err
+}
+
+// Destroy - only marks instance as stopped, existing cluster is never deleted.
+func (si *staticInstance) Destroy(timeout time.Duration) error {
+	logrus.Infof("Disconnecting from existing cluster %s", si.id)
+	si.started = false
+	return nil
+}
+
+func (p *staticProvider) getProviderID(provider string) int {
+	val, ok := p.indexes[provider]
+	if ok {
+		val++
+	} else {
+		val = 1
+	}
+	p.indexes[provider] = val
+	return val
+}
+
+func (p *staticProvider) CreateCluster(config *config.ClusterProviderConfig, factory k8s.ValidationFactory,
+	manager execmanager.ExecutionManager,
+	instanceOptions providers.InstanceOptions) (providers.ClusterInstance, error) {
+	err := p.ValidateConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	p.Lock()
+	defer p.Unlock()
+	index := p.getProviderID(config.Name)
+	if index > len(config.Static.Clusters) {
+		return nil, errors.Errorf("all %v static clusters of %v are already used", len(config.Static.Clusters), config.Name)
+	}
+	id := fmt.Sprintf("%s-%d", config.Name, index)
+
+	var reset []string
+	if script, ok := config.Scripts[resetScript]; ok {
+		reset = utils.ParseScript(script)
+	}
+
+	clusterInstance := &staticInstance{
+		manager:        manager,
+		root:           path.Join(p.root, id),
+		id:             id,
+		cluster:        config.Static.Clusters[index-1],
+		resetScript:    reset,
+		config:         config,
+		factory:        factory,
+		shellInterface: shell.NewManager(manager, id, config, instanceOptions),
+	}
+
+	return clusterInstance, nil
+}
+
+// CleanupClusters - does nothing, since existing clusters are never deleted.
+func (p *staticProvider) CleanupClusters(ctx context.Context, config *config.ClusterProviderConfig,
+	manager execmanager.ExecutionManager, instanceOptions providers.InstanceOptions) {
+}
+
+func (p *staticProvider) ValidateConfig(config *config.ClusterProviderConfig) error {
+	if config.Static == nil || len(config.Static.Clusters) == 0 {
+		return errors.New("no static clusters are specified")
+	}
+	if config.Instances > len(config.Static.Clusters) {
+		return errors.Errorf("instances %v exceeds a number of static clusters %v", config.Instances, len(config.Static.Clusters))
+	}
+	for i, cluster := range config.Static.Clusters {
+		if cluster.KubeConfig != "" && !utils.FileExists(cluster.KubeConfig) {
+			return errors.Errorf("kubeconfig %v of static cluster %v is not found", cluster.KubeConfig, i+1)
+		}
+	}
+	for _, envVar := range config.EnvCheck {
+		if os.Getenv(envVar) == "" {
+			return errors.Errorf("environment variable are not specified %s Required variables: %v", envVar, config.EnvCheck)
+		}
+	}
+	for name := range config.Scripts {
+		if name != resetScript {
+			return errors.Errorf("unsupported script %v, only %v script could be specified", name, resetScript)
+		}
+	}
+	return nil
+}
+
+// NewStaticClusterProvider - Creates new provider of existing clusters
+func NewStaticClusterProvider(root string) providers.ClusterProvider {
+	utils.ClearFolder(root, true)
+	return &staticProvider{
+		root:    root,
+		indexes: map[string]int{},
+	}
+}
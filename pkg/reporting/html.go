@@ -0,0 +1,230 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reporting
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// timelineBar - a test execution or cluster operation displayed on timeline.
+type timelineBar struct {
+	Label  string
+	Status string
+	Left   float64 // Offset from start of testing, in percents.
+	Width  float64 // Duration, in percents.
+}
+
+// timelineRow - all bars of cluster instance.
+type timelineRow struct {
+	Instance string
+	Bars     []*timelineBar
+}
+
+type htmlReport struct {
+	*Summary
+	Statuses map[string]int
+	Timeline []*timelineRow
+}
+
+// WriteHTML - writes a self-contained HTML report of summary, links to files are relative to baseDir.
+func WriteHTML(w io.Writer, summary *Summary, baseDir string) error {
+	tmpl, err := template.New("report").Funcs(template.FuncMap{
+		"link":     fileLink(baseDir),
+		"duration": formatDuration,
+		"time": func(t time.Time) string {
+			if t.IsZero() {
+				return ""
+			}
+			return t.Format("15:04:05")
+		},
+	}).Parse(htmlTemplate)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(w, &htmlReport{
+		Summary:  summary,
+		Statuses: summary.CountStatuses(),
+		Timeline: buildTimeline(summary),
+	})
+}
+
+// fileLink - returns a link to file relative to report folder. Files outside of it are linked with "../" as well, since
+// report is published together with root folder.
+func fileLink(baseDir string) func(string) template.URL {
+	return func(file string) template.URL {
+		if file == "" {
+			return ""
+		}
+		if abs, err := filepath.Abs(file); err == nil {
+			if rel, err := filepath.Rel(baseDir, abs); err == nil {
+				file = rel
+			}
+		}
+		return template.URL(filepath.ToSlash(file))
+	}
+}
+
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Millisecond).String()
+}
+
+func buildTimeline(summary *Summary) []*timelineRow {
+	total := summary.Duration
+	if total <= 0 {
+		return nil
+	}
+	rows := map[string]*timelineRow{}
+	var order []string
+	addBar := func(instance, label, status string, started time.Time, duration time.Duration) {
+		if started.IsZero() {
+			return
+		}
+		row, ok := rows[instance]
+		if !ok {
+			row = &timelineRow{Instance: instance}
+			rows[instance] = row
+			order = append(order, instance)
+		}
+		left := float64(started.Sub(summary.Started)) * 100 / float64(total)
+		width := float64(duration) * 100 / float64(total)
+		if left < 0 {
+			left = 0
+		}
+		if left+width > 100 {
+			width = 100 - left
+		}
+		row.Bars = append(row.Bars, &timelineBar{
+			Label:  fmt.Sprintf("%v %v (%v)", label, status, formatDuration(duration)),
+			Status: status,
+			Left:   left,
+			Width:  width,
+		})
+	}
+	for _, cluster := range summary.Clusters {
+		for _, inst := range cluster.Instances {
+			for _, op := range inst.Operations {
+				addBar(inst.ID, "cluster "+op.Name, op.Status, op.Started, op.Duration)
+			}
+		}
+	}
+	for _, execution := range summary.Executions {
+		for _, group := range execution.Groups {
+			for _, test := range group.Tests {
+				for _, exec := range test.Executions {
+					for _, inst := range exec.Instances {
+						addBar(inst, test.Name, exec.Status, exec.Started, exec.Duration)
+					}
+				}
+			}
+		}
+	}
+	sort.Strings(order)
+	result := make([]*timelineRow, 0, len(order))
+	for _, inst := range order {
+		result = append(result, rows[inst])
+	}
+	return result
+}
+
+const htmlTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Cloud testing report</title>
+<style>
+body { font-family: sans-serif; font-size: 14px; margin: 20px; }
+table { border-collapse: collapse; margin: 5px 0 15px 0; }
+th, td { border: 1px solid #ccc; padding: 3px 8px; text-align: left; vertical-align: top; }
+details { margin: 5px 0 5px 15px; }
+summary { cursor: pointer; font-weight: bold; }
+.success { background: #c8e6c9; }
+.failed, .timeout { background: #ffcdd2; }
+.skipped, .skipped-no-clusters { background: #fff9c4; }
+.rerun-request, .in-progress { background: #bbdefb; }
+.not-available { background: #e0e0e0; }
+.timeline { position: relative; width: 100%; }
+.timeline-row { display: flex; align-items: center; margin: 2px 0; }
+.timeline-name { width: 200px; flex-shrink: 0; overflow: hidden; }
+.timeline-bars { position: relative; flex-grow: 1; height: 18px; background: #f5f5f5; }
+.timeline-bar { position: absolute; top: 0; height: 18px; min-width: 2px; border-right: 1px solid #fff; box-sizing: border-box; }
+.hidden { display: none; }
+</style>
+</head>
+<body>
+<h1>Cloud testing report</h1>
+<p>Started: {{.Started.Format "2006-01-02 15:04:05"}}, duration: {{duration .Duration}}</p>
+<p id="filters">Show:
+{{range $status, $count := .Statuses}}<label class="{{$status}}"><input type="checkbox" value="{{$status}}" checked onchange="applyFilters()"> {{$status}} ({{$count}})</label>
+{{end}}</p>
+<h2>Tests</h2>
+{{range .Executions}}<details open>
+<summary>{{.Name}}</summary>
+{{range .Groups}}<details open>
+<summary>{{.Name}}</summary>
+<table>
+<tr><th>Test</th><th>Status</th><th>Cluster</th><th>Duration</th><th>Executions</th><th>Artifacts</th></tr>
+{{range .Tests}}<tr class="test" data-status="{{.ReportedStatus}}">
+<td>{{.Name}}{{if .Cases}}<details><summary>{{len .Cases}} test cases</summary>{{range .Cases}}<div class="{{.Status}}">{{.Name}} {{duration .Duration}}{{if .Message}}: {{.Message}}{{end}}</div>{{end}}</details>{{end}}</td>
+<td class="{{.Status}}">{{.Status}}{{if .Flaky}} (flaky){{end}}{{if .SkipMessage}}: {{.SkipMessage}}{{end}}{{if .FailureCategory}}<div>{{.FailureCategory}}: {{.FailureMessage}}</div>{{end}}{{if .Quarantine}}<div>{{.Quarantine}}</div>{{end}}</td>
+<td>{{.Cluster}}</td>
+<td>{{duration .Duration}}</td>
+<td>{{range .Executions}}<div class="{{.Status}}">#{{.Retry}} {{time .Started}} {{duration .Duration}} {{range .Instances}}{{.}} {{end}}{{if .OutputFile}}<a href="{{link .OutputFile}}">output</a>{{end}}{{range .Diagnostics}} <a href="{{link .}}">diagnostics</a>{{end}}</div>{{end}}</td>
+<td>{{range .ArtifactDirectories}}<div><a href="{{link .}}">{{link .}}</a></div>{{end}}</td>
+</tr>
+{{end}}</table>
+</details>
+{{end}}</details>
+{{end}}
+<h2>Timeline</h2>
+<div class="timeline">
+{{range .Timeline}}<div class="timeline-row">
+<div class="timeline-name">{{.Instance}}</div>
+<div class="timeline-bars">{{range .Bars}}<div class="timeline-bar {{.Status}}" title="{{.Label}}" style="left: {{printf "%.3f" .Left}}%; width: {{printf "%.3f" .Width}}%;"></div>{{end}}</div>
+</div>
+{{end}}</div>
+<h2>Cluster operations</h2>
+<table>
+<tr><th>Instance</th><th>Operation</th><th>Attempt</th><th>Status</th><th>Started</th><th>Duration</th><th>Error</th></tr>
+{{range .Clusters}}{{range .Instances}}{{$inst := .}}{{range .Operations}}<tr>
+<td>{{$inst.ID}}</td>
+<td>{{.Name}}</td>
+<td>{{.Attempt}}</td>
+<td class="{{.Status}}">{{.Status}}</td>
+<td>{{time .Started}}</td>
+<td>{{duration .Duration}}</td>
+<td>{{if .FailureCategory}}{{.FailureCategory}}: {{.FailureMessage}}<br>{{end}}{{.Error}}{{if .LogFile}} <a href="{{link .LogFile}}">log</a>{{end}}</td>
+</tr>
+{{end}}{{end}}{{end}}</table>
+<script>
+function applyFilters() {
+	var shown = {};
+	document.querySelectorAll("#filters input").forEach(function (input) {
+		shown[input.value] = input.checked;
+	});
+	document.querySelectorAll("tr.test").forEach(function (row) {
+		row.classList.toggle("hidden", !shown[row.dataset.status]);
+	});
+}
+</script>
+</body>
+</html>
+`
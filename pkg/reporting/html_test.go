@@ -0,0 +1,101 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reporting
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteHTML(t *testing.T) {
+	started := time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+	summary := &Summary{
+		Started:  started,
+		Duration: 100 * time.Second,
+		Executions: []*ExecutionSummary{{
+			Name: "simple",
+			Groups: []*GroupSummary{{
+				Name: "a_provider",
+				Tests: []*TestSummary{{
+					Name:   "TestFail",
+					Status: "failed",
+					Executions: []*TestExecutionSummary{{
+						Retry:      1,
+						Status:     "failed",
+						Instances:  []string{"a_provider-1"},
+						Started:    started.Add(50 * time.Second),
+						Duration:   25 * time.Second,
+						OutputFile: "/results/a_provider-1/001-TestFail-run.log",
+					}},
+					ArtifactDirectories: []string{"/results/a_provider-1/TestFail"},
+					Cases: []*TestCaseSummary{
+						{Name: "TestFail/pass", Status: "success", Duration: time.Second},
+						{Name: "TestFail/fail", Status: "failed", Message: "Test execution failed TestFail/fail", Duration: 2 * time.Second},
+					},
+				}, {
+					Name:       "TestQuarantined",
+					Status:     "failed",
+					Quarantine: "Quarantined: known bug (pattern: ^TestQuarantined$)",
+				}},
+			}},
+		}},
+		Clusters: []*ClusterSummary{{
+			Name: "a_provider",
+			Instances: []*InstanceSummary{{
+				ID:    "a_provider-1",
+				State: "shutdown",
+				Operations: []*OperationSummary{{
+					Name:     "start",
+					Status:   "success",
+					Attempt:  1,
+					Started:  started,
+					Duration: 10 * time.Second,
+				}},
+			}},
+		}},
+	}
+
+	out := &strings.Builder{}
+	require.NoError(t, WriteHTML(out, summary, "/results/reports"))
+	report := out.String()
+	require.Contains(t, report, `<input type="checkbox" value="failed" checked onchange="applyFilters()"> failed (1)`)
+	require.Contains(t, report, `<input type="checkbox" value="skipped" checked onchange="applyFilters()"> skipped (1)`)
+	require.Contains(t, report, `<tr class="test" data-status="skipped">`)
+	require.Contains(t, report, `href="../a_provider-1/001-TestFail-run.log"`)
+	require.Contains(t, report, `href="../a_provider-1/TestFail"`)
+	require.Contains(t, report, `<summary>2 test cases</summary><div class="success">TestFail/pass 1s</div>`+
+		`<div class="failed">TestFail/fail 2s: Test execution failed TestFail/fail</div></details>`)
+	require.Contains(t, report, `class="timeline-bar success" title="cluster start success (10s)" style="left: 0.000%; width: 10.000%;"`)
+	require.Contains(t, report, `class="timeline-bar failed" title="TestFail failed (25s)" style="left: 50.000%; width: 25.000%;"`)
+
+	// Quarantined failures are counted as skipped in JSON as well.
+	out.Reset()
+	require.NoError(t, WriteJSON(out, summary))
+	require.Contains(t, out.String(), `"statuses": {
+    "failed": 1,
+    "skipped": 1
+  }`)
+	require.Contains(t, out.String(), `"cases": [
+                {
+                  "name": "TestFail/pass",
+                  "status": "success",
+                  "duration": 1000000000
+                },`)
+}
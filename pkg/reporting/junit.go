@@ -16,17 +16,36 @@
 
 package reporting
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"path/filepath"
+)
 
 const (
 	// TimeCommentFormat is a format for printing readable time suite comment
 	TimeCommentFormat = "Suite was running for %v"
+	// FlakyProperty - a name of test case property set for flaky tests.
+	FlakyProperty = "flaky"
 )
 
 // JUnitFile - JUnitFile
 type JUnitFile struct {
 	XMLName xml.Name `xml:"testsuites"`
-	Suites  []*Suite
+	Suites  []*Suite `xml:"testsuite"`
+}
+
+// ReadJUnitFile - reads JUnit report from file.
+func ReadJUnitFile(fileName string) (*JUnitFile, error) {
+	content, err := ioutil.ReadFile(filepath.Clean(fileName))
+	if err != nil {
+		return nil, err
+	}
+	report := &JUnitFile{}
+	if err := xml.Unmarshal(content, report); err != nil {
+		return nil, err
+	}
+	return report, nil
 }
 
 // Suite - Suite
@@ -38,8 +57,8 @@ type Suite struct {
 	Name        string      `xml:"name,attr"`
 	Properties  []*Property `xml:"properties>property,omitempty"`
 	TimeComment string      `xml:",comment"`
-	TestCases   []*TestCase
-	Suites      []*Suite
+	TestCases   []*TestCase `xml:"testcase"`
+	Suites      []*Suite    `xml:"testsuite"`
 }
 
 // SuiteDetails holds additional information about test suite.
@@ -50,13 +69,25 @@ type SuiteDetails struct {
 
 // TestCase - TestCase
 type TestCase struct {
-	XMLName     xml.Name     `xml:"testcase"`
-	Classname   string       `xml:"classname,attr"`
-	Name        string       `xml:"name,attr"`
-	Time        string       `xml:"time,attr"`
-	Cluster     string       `xml:"cluster_instance,attr"`
-	SkipMessage *SkipMessage `xml:"skipped,omitempty"`
-	Failure     *Failure     `xml:"failure,omitempty"`
+	XMLName       xml.Name        `xml:"testcase"`
+	Classname     string          `xml:"classname,attr"`
+	Name          string          `xml:"name,attr"`
+	Time          string          `xml:"time,attr"`
+	Cluster       string          `xml:"cluster_instance,attr"`
+	Properties    []*Property     `xml:"properties>property,omitempty"`
+	SkipMessage   *SkipMessage    `xml:"skipped,omitempty"`
+	Failure       *Failure        `xml:"failure,omitempty"`
+	FlakyFailures []*FlakyFailure `xml:"flakyFailure,omitempty"`
+}
+
+// IsFlaky - checks if test case is marked as flaky.
+func (tc *TestCase) IsFlaky() bool {
+	for _, property := range tc.Properties {
+		if property.Name == FlakyProperty {
+			return property.Value == "true"
+		}
+	}
+	return false
 }
 
 // SkipMessage - JUnitSkipMessage contains the reason why a testcase was skipped.
@@ -70,6 +101,13 @@ type Property struct {
 	Value string `xml:"value,attr"`
 }
 
+// FlakyFailure - contains data related to a failed execution of flaky test.
+type FlakyFailure struct {
+	Message  string `xml:"message,attr"`
+	Type     string `xml:"type,attr"`
+	Contents string `xml:",chardata"`
+}
+
 // Failure -  contains data related to a failed test.
 type Failure struct {
 	Message  string `xml:"message,attr"`
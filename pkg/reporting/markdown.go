@@ -0,0 +1,292 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reporting
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// DefaultMarkdownOutputLines - a default number of last output lines shown for failed tests.
+	DefaultMarkdownOutputLines = 20
+	// DefaultMarkdownMaxSize - a default limit of Markdown report size, GitHub comments are limited by 65536 characters.
+	DefaultMarkdownMaxSize = 60000
+
+	maxMarkdownErrorSize = 300
+)
+
+// MarkdownOptions - options of Markdown report.
+type MarkdownOptions struct {
+	OutputLines int // A number of last output lines shown for failed tests.
+	MaxSize     int // A maximum size of report in bytes, details of failed tests, then other lines are omitted if it is exceeded.
+}
+
+// WriteMarkdown - writes a Markdown report of summary, suitable for pull request comments.
+func WriteMarkdown(w io.Writer, summary *Summary, options MarkdownOptions) error {
+	if options.OutputLines <= 0 {
+		options.OutputLines = DefaultMarkdownOutputLines
+	}
+	if options.MaxSize <= 0 {
+		options.MaxSize = DefaultMarkdownMaxSize
+	}
+
+	report := &strings.Builder{}
+	writeMarkdownTotals(report, summary)
+	writeMarkdownCauses(report, summary)
+	writeMarkdownClusterFailures(report, summary)
+	writeMarkdownFlaky(report, summary)
+	writeMarkdownQuarantined(report, summary)
+
+	var failed []string
+	for _, execution := range summary.Executions {
+		for _, group := range execution.Groups {
+			for _, test := range group.Tests {
+				if status := test.ReportedStatus(); status == "failed" || status == "timeout" {
+					failed = append(failed, markdownFailedTest(execution, group, test, options.OutputLines))
+				}
+			}
+		}
+	}
+	// A space reserved for a note about omitted tests.
+	const omittedNoteSize = 100
+	if report.Len()+omittedNoteSize > options.MaxSize {
+		// Lists of tests and cluster failures do not fit as well, so report is cut by lines.
+		content, limit := report.String(), options.MaxSize-omittedNoteSize
+		if limit < 0 {
+			limit = 0
+		}
+		content = content[:strings.LastIndex(content[:limit], "\n")+1]
+		content += fmt.Sprintf("\nReport is truncated and %d failed tests are omitted, since report size is limited.\n",
+			len(failed))
+		if len(content) > options.MaxSize {
+			content = content[:options.MaxSize]
+		}
+		_, err := io.WriteString(w, content)
+		return err
+	}
+	if len(failed) > 0 {
+		_, _ = fmt.Fprintf(report, "\n## Failed tests\n")
+	}
+	for i, section := range failed {
+		if report.Len()+len(section)+omittedNoteSize > options.MaxSize {
+			_, _ = fmt.Fprintf(report, "\n%d of %d failed tests are omitted, since report size is limited.\n",
+				len(failed)-i, len(failed))
+			break
+		}
+		report.WriteString(section)
+	}
+	_, err := io.WriteString(w, report.String())
+	return err
+}
+
+func writeMarkdownTotals(report *strings.Builder, summary *Summary) {
+	table := &strings.Builder{}
+	totals := map[string]int{}
+	totalFlaky := 0
+	for _, execution := range summary.Executions {
+		for _, group := range execution.Groups {
+			counts := map[string]int{}
+			flaky := 0
+			for _, test := range group.Tests {
+				counts[test.ReportedStatus()]++
+				totals[test.ReportedStatus()]++
+				if test.Flaky {
+					flaky++
+				}
+			}
+			totalFlaky += flaky
+			_, _ = fmt.Fprintf(table, "| %v | %v | %d | %d | %d | %d | %d | %d |\n",
+				markdownCell(execution.Name), markdownCell(group.Name), counts["success"], counts["failed"],
+				counts["timeout"], counts["skipped"]+counts["skipped-no-clusters"], flaky, len(group.Tests))
+		}
+	}
+	_, _ = fmt.Fprintf(report, "# Cloud testing results\n\n")
+	_, _ = fmt.Fprintf(report, "Duration: %v, passed: %d, failed: %d, timeout: %d, skipped: %d, flaky: %d\n\n",
+		formatDuration(summary.Duration), totals["success"], totals["failed"], totals["timeout"],
+		totals["skipped"]+totals["skipped-no-clusters"], totalFlaky)
+	_, _ = fmt.Fprintf(report, "| Execution | Clusters | Passed | Failed | Timeout | Skipped | Flaky | Total |\n")
+	_, _ = fmt.Fprintf(report, "|---|---|---|---|---|---|---|---|\n")
+	report.WriteString(table.String())
+}
+
+// writeMarkdownTestList - writes a list of tests matching the filter with a description of each test.
+func writeMarkdownTestList(report *strings.Builder, summary *Summary, title string,
+	filter func(test *TestSummary) bool, describe func(test *TestSummary) string) {
+	header := false
+	for _, execution := range summary.Executions {
+		for _, group := range execution.Groups {
+			for _, test := range group.Tests {
+				if !filter(test) {
+					continue
+				}
+				if !header {
+					_, _ = fmt.Fprintf(report, "\n## %v\n\n", title)
+					header = true
+				}
+				_, _ = fmt.Fprintf(report, "* %v / %v / %v: %v\n", execution.Name, group.Name, test.Name, describe(test))
+			}
+		}
+	}
+}
+
+func writeMarkdownFlaky(report *strings.Builder, summary *Summary) {
+	writeMarkdownTestList(report, summary, "Flaky tests", func(test *TestSummary) bool {
+		return test.Flaky
+	}, func(test *TestSummary) string {
+		var executions []string
+		for _, exec := range test.Executions {
+			executions = append(executions, fmt.Sprintf("#%d %v", exec.Retry, exec.Status))
+		}
+		return strings.Join(executions, ", ")
+	})
+}
+
+func writeMarkdownQuarantined(report *strings.Builder, summary *Summary) {
+	writeMarkdownTestList(report, summary, "Quarantined failures", func(test *TestSummary) bool {
+		return test.ReportedStatus() != test.Status
+	}, func(test *TestSummary) string {
+		return test.Quarantine
+	})
+}
+
+func writeMarkdownClusterFailures(report *strings.Builder, summary *Summary) {
+	header := false
+	for _, cluster := range summary.Clusters {
+		for _, inst := range cluster.Instances {
+			for _, op := range inst.Operations {
+				if op.Name != "start" || op.Status == "success" || op.Status == "in-progress" {
+					continue
+				}
+				if !header {
+					_, _ = fmt.Fprintf(report, "\n## Cluster failures\n\n")
+					_, _ = fmt.Fprintf(report, "| Instance | Attempt | Duration | Cause | Error |\n")
+					_, _ = fmt.Fprintf(report, "|---|---|---|---|---|\n")
+					header = true
+				}
+				_, _ = fmt.Fprintf(report, "| %v | %d | %v | %v | %v |\n",
+					markdownCell(inst.ID), op.Attempt, formatDuration(op.Duration), markdownCell(op.FailureCategory),
+					truncate(markdownCell(op.Error), maxMarkdownErrorSize))
+			}
+		}
+	}
+}
+
+// writeMarkdownCauses - writes numbers of failed tests and cluster starts by failure category,
+// the table is omitted if no failure is classified.
+func writeMarkdownCauses(report *strings.Builder, summary *Summary) {
+	const unclassified = "Unclassified"
+	var categories []string
+	tests := map[string]int{}
+	clusters := map[string]int{}
+	count := func(counts map[string]int, category string) {
+		if category == "" {
+			category = unclassified
+		}
+		if tests[category] == 0 && clusters[category] == 0 {
+			categories = append(categories, category)
+		}
+		counts[category]++
+	}
+	for _, execution := range summary.Executions {
+		for _, group := range execution.Groups {
+			for _, test := range group.Tests {
+				if status := test.ReportedStatus(); status == "failed" || status == "timeout" {
+					count(tests, test.FailureCategory)
+				}
+			}
+		}
+	}
+	for _, cluster := range summary.Clusters {
+		for _, inst := range cluster.Instances {
+			for _, op := range inst.Operations {
+				if op.Name == "start" && op.Status == "failed" {
+					count(clusters, op.FailureCategory)
+				}
+			}
+		}
+	}
+	if len(categories) == 0 || (len(categories) == 1 && categories[0] == unclassified) {
+		return
+	}
+	_, _ = fmt.Fprintf(report, "\n## Failures by cause\n\n")
+	_, _ = fmt.Fprintf(report, "| Cause | Tests | Cluster starts |\n")
+	_, _ = fmt.Fprintf(report, "|---|---|---|\n")
+	for _, category := range categories {
+		_, _ = fmt.Fprintf(report, "| %v | %d | %d |\n", markdownCell(category), tests[category], clusters[category])
+	}
+}
+
+func markdownFailedTest(execution *ExecutionSummary, group *GroupSummary, test *TestSummary, outputLines int) string {
+	section := &strings.Builder{}
+	_, _ = fmt.Fprintf(section, "\n### %v / %v / %v\n\n", execution.Name, group.Name, test.Name)
+	if test.FailureCategory != "" {
+		_, _ = fmt.Fprintf(section, "Cause: %v (%v)\n\n", test.FailureMessage, test.FailureCategory)
+	}
+	for _, testCase := range test.Cases {
+		if testCase.Status == "failed" {
+			_, _ = fmt.Fprintf(section, "* failed test case: %v\n", testCase.Name)
+		}
+	}
+	for _, exec := range test.Executions {
+		_, _ = fmt.Fprintf(section, "* #%d %v on %v, %v\n", exec.Retry, exec.Status,
+			strings.Join(exec.Instances, ", "), formatDuration(exec.Duration))
+	}
+	if count := len(test.Executions); count > 0 {
+		for _, dir := range test.Executions[count-1].Diagnostics {
+			_, _ = fmt.Fprintf(section, "* diagnostics: `%v`\n", dir)
+		}
+		if output := lastLines(test.Executions[count-1].OutputFile, outputLines); output != "" {
+			_, _ = fmt.Fprintf(section, "\n```\n%v\n```\n", output)
+		}
+	}
+	return section.String()
+}
+
+// lastLines - returns last lines of file, or empty string if file could not be read.
+func lastLines(fileName string, count int) string {
+	if fileName == "" {
+		return ""
+	}
+	content, err := ioutil.ReadFile(filepath.Clean(fileName))
+	if err != nil {
+		return ""
+	}
+	lines := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+	if len(lines) > count {
+		lines = lines[len(lines)-count:]
+	}
+	// Output should not close code block.
+	return strings.ReplaceAll(strings.Join(lines, "\n"), "```", "'''")
+}
+
+// markdownCell - escapes value to be placed into table cell.
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.Join(strings.Fields(value), " ")
+}
+
+func truncate(value string, size int) string {
+	runes := []rune(value)
+	if len(runes) <= size {
+		return value
+	}
+	return string(runes[:size]) + "..."
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reporting
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newMarkdownSummary(t *testing.T, dir string, failedCount int) *Summary {
+	var output []string
+	for i := 1; i <= 30; i++ {
+		output = append(output, fmt.Sprintf("output line %d", i))
+	}
+	outputFile := path.Join(dir, "TestFail-run.log")
+	require.NoError(t, ioutil.WriteFile(outputFile, []byte(strings.Join(output, "\n")+"\n"), os.ModePerm))
+
+	group := &GroupSummary{
+		Name: "a_provider",
+		Tests: []*TestSummary{
+			{Name: "TestPass", Status: "success"},
+			{Name: "TestSkip", Status: "skipped-no-clusters"},
+		},
+	}
+	for i := 0; i < failedCount; i++ {
+		group.Tests = append(group.Tests, &TestSummary{
+			Name:   fmt.Sprintf("TestFail%d", i),
+			Status: "failed",
+			Executions: []*TestExecutionSummary{
+				{Retry: 1, Status: "rerun-request", Instances: []string{"a_provider-1"}, Duration: time.Second},
+				{Retry: 2, Status: "failed", Instances: []string{"a_provider-2"}, Duration: 2 * time.Second, OutputFile: outputFile},
+			},
+			Cases: []*TestCaseSummary{
+				{Name: fmt.Sprintf("TestFail%d/pass", i), Status: "success"},
+				{Name: fmt.Sprintf("TestFail%d/fail", i), Status: "failed"},
+			},
+		})
+	}
+	return &Summary{
+		Duration:   time.Minute,
+		Executions: []*ExecutionSummary{{Name: "simple", Groups: []*GroupSummary{group}}},
+		Clusters: []*ClusterSummary{{
+			Name: "a_provider",
+			Instances: []*InstanceSummary{{
+				ID: "a_provider-1",
+				Operations: []*OperationSummary{
+					{Name: "start", Status: "failed", Attempt: 1, Duration: time.Second, Error: "exit status 2\n| details"},
+					{Name: "start", Status: "success", Attempt: 2, Duration: time.Second},
+				},
+			}},
+		}},
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "cloud-test-markdown")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	out := &strings.Builder{}
+	require.NoError(t, WriteMarkdown(out, newMarkdownSummary(t, dir, 1), MarkdownOptions{OutputLines: 5}))
+	report := out.String()
+	require.Contains(t, report, "| simple | a_provider | 1 | 1 | 0 | 1 | 0 | 3 |\n")
+	require.Contains(t, report, "| a_provider-1 | 1 | 1s |  | exit status 2 \\| details |\n")
+	require.NotContains(t, report, "## Failures by cause")
+	require.NotContains(t, report, "| a_provider-1 | 2 |")
+	require.Contains(t, report, "### simple / a_provider / TestFail0\n\n* failed test case: TestFail0/fail\n")
+	require.NotContains(t, report, "TestFail0/pass")
+	require.Contains(t, report, "* #1 rerun-request on a_provider-1, 1s\n* #2 failed on a_provider-2, 2s\n")
+	require.Contains(t, report, "```\noutput line 26\noutput line 27\noutput line 28\noutput line 29\noutput line 30\n```\n")
+	require.NotContains(t, report, "output line 25")
+}
+
+func TestWriteMarkdownCauses(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "cloud-test-markdown")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	summary := newMarkdownSummary(t, dir, 2)
+	tests := summary.Executions[0].Groups[0].Tests
+	tests[2].FailureCategory = "INFRA"
+	tests[2].FailureMessage = "Registry is not available"
+	summary.Clusters[0].Instances[0].Operations[0].FailureCategory = "INFRA"
+
+	out := &strings.Builder{}
+	require.NoError(t, WriteMarkdown(out, summary, MarkdownOptions{}))
+	report := out.String()
+	require.Contains(t, report, "## Failures by cause\n\n| Cause | Tests | Cluster starts |\n|---|---|---|\n"+
+		"| INFRA | 1 | 1 |\n| Unclassified | 1 | 0 |\n")
+	require.Contains(t, report, "| a_provider-1 | 1 | 1s | INFRA | exit status 2 \\| details |\n")
+	require.Contains(t, report, "### simple / a_provider / TestFail0\n\nCause: Registry is not available (INFRA)\n")
+	require.NotContains(t, report, "### simple / a_provider / TestFail1\n\nCause:")
+}
+
+func TestWriteMarkdownMaxSize(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "cloud-test-markdown")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	out := &strings.Builder{}
+	require.NoError(t, WriteMarkdown(out, newMarkdownSummary(t, dir, 100), MarkdownOptions{MaxSize: 10000}))
+	report := out.String()
+	require.LessOrEqual(t, len(report), 10000)
+	require.Contains(t, report, "### simple / a_provider / TestFail0\n")
+	require.NotContains(t, report, "TestFail99")
+	require.Regexp(t, "\n[0-9]+ of 100 failed tests are omitted, since report size is limited.\n$", report)
+}
+
+func TestWriteMarkdownMaxSizeLists(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "cloud-test-markdown")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	summary := newMarkdownSummary(t, dir, 1)
+	group := summary.Executions[0].Groups[0]
+	for i := 0; i < 500; i++ {
+		group.Tests = append(group.Tests, &TestSummary{Name: fmt.Sprintf("TestFlaky%d", i), Status: "success", Flaky: true})
+	}
+
+	out := &strings.Builder{}
+	require.NoError(t, WriteMarkdown(out, summary, MarkdownOptions{MaxSize: 5000}))
+	report := out.String()
+	require.LessOrEqual(t, len(report), 5000)
+	require.Contains(t, report, "## Flaky tests\n")
+	require.NotContains(t, report, "TestFlaky499")
+	require.NotContains(t, report, "### simple / a_provider / TestFail0\n")
+	require.Regexp(t, "\n\\* [^\n]+\n\nReport is truncated and 1 failed tests are omitted, since report size is limited.\n$", report)
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reporting
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	// SummarySuiteName is a name of root suite of cloudtest report.
+	SummarySuiteName = "All tests"
+	// ClusterFailuresSuiteName is a name of suite with cluster start failures.
+	ClusterFailuresSuiteName = "Cluster failures"
+)
+
+// MergeJUnitFiles - merges reports of few cloudtest runs into one report. Suites are merged by names, test cases with
+// same name inside of same suite are treated as retries and test case from the latest report is used.
+// Cluster start failures are never de-duplicated. Tests, failures and time of all suites are recalculated.
+func MergeJUnitFiles(reports ...*JUnitFile) *JUnitFile {
+	summarySuite := &Suite{
+		Name: SummarySuiteName,
+	}
+	for _, report := range reports {
+		for _, suite := range report.Suites {
+			if suite.Name == SummarySuiteName {
+				mergeSuites(summarySuite, suite.Suites)
+			} else {
+				mergeSuites(summarySuite, []*Suite{suite})
+			}
+		}
+	}
+	summarySuite.Recount()
+	return &JUnitFile{
+		Suites: []*Suite{summarySuite},
+	}
+}
+
+func mergeSuites(target *Suite, suites []*Suite) {
+	for _, suite := range suites {
+		var existing *Suite
+		for _, s := range target.Suites {
+			if s.Name == suite.Name {
+				existing = s
+				break
+			}
+		}
+		if existing == nil {
+			existing = &Suite{
+				Name:       suite.Name,
+				Properties: suite.Properties,
+			}
+			target.Suites = append(target.Suites, existing)
+		}
+		if suite.Name == ClusterFailuresSuiteName {
+			existing.TestCases = append(existing.TestCases, suite.TestCases...)
+		} else {
+			mergeTestCases(existing, suite.TestCases)
+		}
+		mergeSuites(existing, suite.Suites)
+	}
+}
+
+func mergeTestCases(target *Suite, testCases []*TestCase) {
+	for _, testCase := range testCases {
+		replaced := false
+		for i, tc := range target.TestCases {
+			if tc.Name == testCase.Name {
+				target.TestCases[i] = testCase
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			target.TestCases = append(target.TestCases, testCase)
+		}
+	}
+}
+
+// Recount - recalculates tests, failures and time of suite and all nested suites based on test cases, the same way
+// cloudtest counts them in its report.
+func (s *Suite) Recount() time.Duration {
+	s.Tests = len(s.TestCases)
+	s.Failures = 0
+	duration := time.Duration(0)
+	for _, testCase := range s.TestCases {
+		if testCase.Failure != nil {
+			s.Failures++
+		}
+		seconds, err := strconv.ParseFloat(testCase.Time, 64)
+		if err == nil {
+			duration += time.Duration(seconds * float64(time.Second))
+		}
+	}
+	for _, suite := range s.Suites {
+		duration += suite.Recount()
+		// Cluster start failures are counted as failures of parent suite, but not as its tests.
+		if suite.Name != ClusterFailuresSuiteName {
+			s.Tests += suite.Tests
+		}
+		s.Failures += suite.Failures
+	}
+	s.Time = fmt.Sprintf("%v", duration.Seconds())
+	s.TimeComment = fmt.Sprintf(TimeCommentFormat, duration.Round(time.Second))
+	return duration
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reporting
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const firstShard = `<testsuites>
+  <testsuite tests="3" failures="2" time="4" name="All tests">
+    <testsuite tests="3" failures="1" time="3" name="simple">
+      <testsuite tests="3" failures="1" time="3" name="a_provider">
+        <testcase classname="" name="TestPass" time="1" cluster_instance="a_provider-1"></testcase>
+        <testcase classname="" name="TestFail" time="1" cluster_instance="a_provider-1">
+          <failure message="Test execution failed TestFail" type="ERROR">output</failure>
+        </testcase>
+        <testsuite tests="1" failures="0" time="1" name="TestRunSuite">
+          <testcase classname="" name="TestA" time="1" cluster_instance="a_provider-1"></testcase>
+        </testsuite>
+      </testsuite>
+    </testsuite>
+    <testsuite tests="1" failures="1" time="1" name="Cluster failures">
+      <testcase classname="" name="Startup-b_provider-1" time="1">
+        <failure message="Cluster start failed b_provider-1" type="ERROR">output</failure>
+      </testcase>
+    </testsuite>
+  </testsuite>
+</testsuites>`
+
+const secondShard = `<testsuites>
+  <testsuite tests="4" failures="0" time="7" name="All tests">
+    <testsuite tests="2" failures="0" time="4" name="simple">
+      <testsuite tests="1" failures="0" time="2" name="a_provider">
+        <testcase classname="" name="TestFail" time="2" cluster_instance="a_provider-2"></testcase>
+      </testsuite>
+      <testsuite tests="1" failures="0" time="2" name="b_provider">
+        <testsuite tests="1" failures="0" time="2" name="TestRunSuite">
+          <testcase classname="" name="TestA" time="2" cluster_instance="b_provider-1"></testcase>
+        </testsuite>
+      </testsuite>
+    </testsuite>
+    <testsuite tests="1" failures="1" time="2" name="Cluster failures">
+      <testcase classname="" name="Startup-b_provider-1" time="2">
+        <failure message="Cluster start failed b_provider-1" type="ERROR">output</failure>
+      </testcase>
+    </testsuite>
+  </testsuite>
+</testsuites>`
+
+func readReport(t *testing.T, content string) *JUnitFile {
+	report := &JUnitFile{}
+	require.NoError(t, xml.Unmarshal([]byte(content), report))
+	return report
+}
+
+func TestMergeJUnitFiles(t *testing.T) {
+	report := MergeJUnitFiles(readReport(t, firstShard), readReport(t, secondShard))
+	require.Len(t, report.Suites, 1)
+
+	summary := report.Suites[0]
+	require.Equal(t, SummarySuiteName, summary.Name)
+	require.Equal(t, 4, summary.Tests)
+	require.Equal(t, 2, summary.Failures)
+	require.Equal(t, "9", summary.Time)
+	require.Equal(t, "Suite was running for 9s", summary.TimeComment)
+
+	require.Len(t, summary.Suites, 2)
+	execSuite := summary.Suites[0]
+	require.Equal(t, "simple", execSuite.Name)
+	require.Equal(t, 4, execSuite.Tests)
+	require.Equal(t, 0, execSuite.Failures)
+	require.Equal(t, "6", execSuite.Time)
+
+	require.Len(t, execSuite.Suites, 2)
+	clusterSuite := execSuite.Suites[0]
+	require.Equal(t, "a_provider", clusterSuite.Name)
+	require.Len(t, clusterSuite.TestCases, 2)
+	require.Equal(t, "TestFail", clusterSuite.TestCases[1].Name)
+	require.Nil(t, clusterSuite.TestCases[1].Failure)
+	require.Equal(t, "a_provider-2", clusterSuite.TestCases[1].Cluster)
+	require.Equal(t, "b_provider", execSuite.Suites[1].Name)
+	require.Equal(t, 1, execSuite.Suites[1].Tests)
+
+	failuresSuite := summary.Suites[1]
+	require.Equal(t, ClusterFailuresSuiteName, failuresSuite.Name)
+	require.Equal(t, 2, failuresSuite.Tests)
+	require.Equal(t, 2, failuresSuite.Failures)
+}
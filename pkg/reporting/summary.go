@@ -0,0 +1,149 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reporting
+
+import "time"
+
+// SummaryVersion - a version of summary schema, it is changed on incompatible changes of JSON report.
+const SummaryVersion = 1
+
+// Summary - a description of testing run, human readable and JSON reports are generated from it.
+// Durations are serialized in nanoseconds, times in RFC 3339 format, zero time means operation was not started.
+type Summary struct {
+	Version    int                 `json:"version"`
+	Started    time.Time           `json:"started"`
+	Duration   time.Duration       `json:"duration"`
+	Statuses   map[string]int      `json:"statuses"` // Numbers of tests by reported status.
+	Flaky      int                 `json:"flaky"`    // A number of flaky tests, they are counted in statuses as well.
+	Executions []*ExecutionSummary `json:"executions"`
+	Clusters   []*ClusterSummary   `json:"clusters"`
+	Config     interface{}         `json:"config,omitempty"` // An effective configuration, after imports and command line options.
+}
+
+// ExecutionSummary - tests of execution grouped by clusters they are executed on.
+type ExecutionSummary struct {
+	Name   string          `json:"name"`
+	Groups []*GroupSummary `json:"groups"`
+}
+
+// GroupSummary - tests executed on a group of clusters.
+type GroupSummary struct {
+	Name  string         `json:"name"`
+	Tests []*TestSummary `json:"tests"`
+}
+
+// TestSummary - a test with all its executions.
+type TestSummary struct {
+	Name                string                  `json:"name"`
+	Key                 string                  `json:"key"`
+	Status              string                  `json:"status"`
+	Flaky               bool                    `json:"flaky,omitempty"` // Test had both failed and passed executions.
+	SkipMessage         string                  `json:"skip-message,omitempty"`
+	Quarantine          string                  `json:"quarantine,omitempty"`       // A quarantine message, failures of quarantined tests are reported as skipped.
+	FailureCategory     string                  `json:"failure-category,omitempty"` // A category of failure assigned by failure rules.
+	FailureMessage      string                  `json:"failure-message,omitempty"`
+	Cluster             string                  `json:"cluster,omitempty"` // Cluster instances of last execution.
+	Started             time.Time               `json:"started"`
+	Duration            time.Duration           `json:"duration"`
+	Executions          []*TestExecutionSummary `json:"executions"`
+	ArtifactDirectories []string                `json:"artifact-directories,omitempty"`
+	// Test cases of suite, Ginkgo specs, go subtests or test cases read from reports of external framework.
+	Cases []*TestCaseSummary `json:"cases,omitempty"`
+}
+
+// TestCaseSummary - a test case run by test, as it is reported into JUnit report.
+type TestCaseSummary struct {
+	Name     string        `json:"name"`
+	Status   string        `json:"status"`            // A reported status, failures of quarantined test cases are skipped.
+	Message  string        `json:"message,omitempty"` // A skip or failure message.
+	Duration time.Duration `json:"duration"`
+}
+
+// TestExecutionSummary - an attempt to execute test.
+type TestExecutionSummary struct {
+	Retry      int           `json:"retry"`
+	Status     string        `json:"status"`
+	Instances  []string      `json:"instances"` // Cluster instances test was assigned to.
+	Started    time.Time     `json:"started"`
+	Duration   time.Duration `json:"duration"`
+	OutputFile string        `json:"output-file,omitempty"`
+	// Directories with Kubernetes diagnostics collected on failure, one per cluster instance.
+	Diagnostics []string `json:"diagnostics,omitempty"`
+}
+
+// ClusterSummary - instances of cluster provider.
+type ClusterSummary struct {
+	Name      string             `json:"name"`
+	Instances []*InstanceSummary `json:"instances"`
+}
+
+// InstanceSummary - a cluster instance with its start and destroy operations.
+type InstanceSummary struct {
+	ID         string              `json:"id"`
+	State      string              `json:"state"`
+	Operations []*OperationSummary `json:"operations"`
+}
+
+// OperationSummary - a start or destroy operation with cluster instance.
+type OperationSummary struct {
+	Name     string        `json:"name"`
+	Status   string        `json:"status"`
+	Attempt  int           `json:"attempt,omitempty"` // A start attempt number.
+	Started  time.Time     `json:"started"`
+	Duration time.Duration `json:"duration"`
+	LogFile  string        `json:"log-file,omitempty"`
+	Error    string        `json:"error,omitempty"`
+	// A category and a message of failure assigned by failure rules.
+	FailureCategory string `json:"failure-category,omitempty"`
+	FailureMessage  string `json:"failure-message,omitempty"`
+}
+
+// ReportedStatus - returns a status test is reported with, failures of quarantined tests are reported as skipped.
+func (t *TestSummary) ReportedStatus() string {
+	if t.Quarantine != "" && (t.Status == "failed" || t.Status == "timeout") {
+		return "skipped"
+	}
+	return t.Status
+}
+
+// CountFlaky - returns a number of flaky tests.
+func (s *Summary) CountFlaky() int {
+	result := 0
+	for _, execution := range s.Executions {
+		for _, group := range execution.Groups {
+			for _, test := range group.Tests {
+				if test.Flaky {
+					result++
+				}
+			}
+		}
+	}
+	return result
+}
+
+// CountStatuses - returns numbers of tests by reported status, so quarantined failures are counted as skipped.
+func (s *Summary) CountStatuses() map[string]int {
+	result := map[string]int{}
+	for _, execution := range s.Executions {
+		for _, group := range execution.Groups {
+			for _, test := range group.Tests {
+				result[test.ReportedStatus()]++
+			}
+		}
+	}
+	return result
+}
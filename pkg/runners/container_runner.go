@@ -0,0 +1,211 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runners
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/model"
+	"github.com/networkservicemesh/cloudtest/pkg/shell"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+const (
+	defaultContainerRuntime = "docker"
+	artifactsDirEnv         = "ARTIFACTS_DIR"
+	kubeConfigEnv           = "KUBECONFIG"
+)
+
+var containerNameRegexp = regexp.MustCompile(`[^a-zA-Z0-9_.-]`)
+
+type containerRunner struct {
+	test   *model.TestEntry
+	config *config.ContainerConfig
+	envMgr shell.EnvironmentManager
+	id     string
+}
+
+// flushWriter - flushes every write, so output of container is streamed into test output file.
+type flushWriter struct {
+	*bufio.Writer
+}
+
+func (w flushWriter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	if err == nil {
+		err = w.Flush()
+	}
+	return n, err
+}
+
+func (runner *containerRunner) Run(timeoutCtx context.Context, env []string, writer *bufio.Writer) error {
+	for _, cmd := range utils.ParseScript(runner.test.RunScript) {
+		if strings.TrimSpace(cmd) == "" {
+			continue
+		}
+		_, _ = writer.WriteString(fmt.Sprintf(">>>>>>Running: %s:<<<<<<\n", cmd))
+		_ = writer.Flush()
+
+		if err := runner.runCmd(timeoutCtx, cmd, env, writer); err != nil {
+			_, _ = writer.WriteString(fmt.Sprintf("error running command: %v\n", err))
+			_ = writer.Flush()
+			return err
+		}
+	}
+	return nil
+}
+
+func (runner *containerRunner) runCmd(ctx context.Context, cmd string, env []string, writer *bufio.Writer) error {
+	name := fmt.Sprintf("cloudtest-%s-%d", containerNameRegexp.ReplaceAllString(runner.id, "-"), time.Now().UnixNano())
+	envFile, err := ioutil.TempFile("", name+"-*.env")
+	if err != nil {
+		return errors.Wrapf(err, "failed to create env file of container %v", name)
+	}
+	defer func() { _ = os.Remove(envFile.Name()) }()
+	args, forwarded, err := runner.runArgs(name, cmd, envFile.Name(), env)
+	if err == nil {
+		_, err = envFile.WriteString(strings.Join(forwarded, "\n") + "\n")
+	}
+	if closeErr := envFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	proc := exec.CommandContext(ctx, runner.runtime(), args...)
+	proc.Stdout = flushWriter{writer}
+	proc.Stderr = proc.Stdout
+	err = proc.Run()
+	if ctx.Err() != nil {
+		// Killing of CLI process does not stop container, so it is removed explicitly.
+		if out, rmErr := exec.Command(runner.runtime(), "rm", "-f", name).CombinedOutput(); rmErr != nil {
+			_, _ = writer.WriteString(fmt.Sprintf("failed to remove container %v: %v %v\n", name, rmErr, string(out)))
+		}
+		return errors.Wrapf(ctx.Err(), "container %v is killed", name)
+	}
+	return errors.Wrapf(err, "failed to run %v in container %v", cmd, name)
+}
+
+// runArgs - returns arguments of run command and variables to write into env file. Package root, kubeconfig files and
+// artifacts directory are mounted into container with same paths, so environment variables could be used as is.
+// Only variables of execution and variables passed by cloudtest are forwarded, environment of host is not.
+// Command is executed without shell, like commands of shell tests executed on host.
+func (runner *containerRunner) runArgs(name, cmd, envFile string, env []string) (args, forwarded []string, err error) {
+	root, err := filepath.Abs(runner.test.ExecutionConfig.PackageRoot)
+	if err != nil {
+		return nil, nil, err
+	}
+	args = []string{"run", "--rm", "--name", name, "-v", root + ":" + root, "-w", root, "--env-file", envFile}
+	mounts := map[string]bool{root: true}
+	forwardedKeys := map[string]bool{}
+	for _, envVar := range runner.test.ExecutionConfig.Env {
+		if key, _, parseErr := utils.ParseVariable(envVar); parseErr == nil {
+			forwardedKeys[key] = true
+		}
+	}
+	environment := map[string]string{}
+	var keys []string
+	for _, envVar := range append(runner.envMgr.GetProcessedEnv(), env...) {
+		key, value, err := utils.ParseVariable(envVar)
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, ok := environment[key]; !ok {
+			keys = append(keys, key)
+		}
+		environment[key] = value
+	}
+	for _, envVar := range env {
+		if key, _, parseErr := utils.ParseVariable(envVar); parseErr == nil {
+			forwardedKeys[key] = true
+		}
+	}
+	for _, key := range keys {
+		if !forwardedKeys[key] {
+			continue
+		}
+		value := environment[key]
+		if runner.isMounted(key) {
+			// KUBECONFIG could hold a list of files, every file is mounted separately.
+			var paths []string
+			for _, path := range filepath.SplitList(value) {
+				if path == "" {
+					continue
+				}
+				if path, err = filepath.Abs(path); err != nil {
+					return nil, nil, err
+				}
+				if !mounts[path] {
+					mounts[path] = true
+					args = append(args, "-v", path+":"+path)
+				}
+				paths = append(paths, path)
+			}
+			value = strings.Join(paths, string(filepath.ListSeparator))
+		}
+		forwarded = append(forwarded, key+"="+value)
+	}
+	// Variables are substituted in the same way as for shell tests executed on host.
+	finalCmd, err := utils.SubstituteVariable(cmd, environment, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	args = append(args, runner.config.Args...)
+	args = append(args, runner.config.Image)
+	return append(args, utils.ParseCommandLine(finalCmd)...), forwarded, nil
+}
+
+// isMounted - checks if environment variable refers to kubeconfig or artifacts directory.
+func (runner *containerRunner) isMounted(key string) bool {
+	return key == artifactsDirEnv || strings.HasPrefix(key, kubeConfigEnv) ||
+		utils.Contains(runner.test.ExecutionConfig.ClusterEnv, key)
+}
+
+func (runner *containerRunner) runtime() string {
+	if runner.config.Runtime != "" {
+		return runner.config.Runtime
+	}
+	return defaultContainerRuntime
+}
+
+func (runner *containerRunner) GetCmdLine() string {
+	return fmt.Sprintf("%s run %s\n%s", runner.runtime(), runner.config.Image, runner.test.RunScript)
+}
+
+// NewContainerRunner - creates a shell script test runner executing commands inside container of execution.
+func NewContainerRunner(ids string, test *model.TestEntry) TestRunner {
+	envMgr := shell.NewEnvironmentManager()
+	_ = envMgr.ProcessEnvironment(ids, "shellrun", os.TempDir(), test.ExecutionConfig.Env, map[string]string{})
+	return &containerRunner{
+		id:     ids,
+		test:   test,
+		config: test.ExecutionConfig.Container,
+		envMgr: envMgr,
+	}
+}
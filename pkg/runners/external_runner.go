@@ -0,0 +1,58 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runners
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"strings"
+
+	"github.com/networkservicemesh/cloudtest/pkg/external"
+	"github.com/networkservicemesh/cloudtest/pkg/model"
+)
+
+type externalRunner struct {
+	TestRunner
+	test *model.TestEntry
+}
+
+func (runner *externalRunner) Run(timeoutCtx context.Context, env []string, writer *bufio.Writer) error {
+	// Reports of previous attempt on the same cluster instance are removed, so only reports of last attempt are read.
+	for _, envVar := range env {
+		if dir := strings.TrimPrefix(envVar, artifactsDirEnv+"="); dir != envVar {
+			files, _ := external.ReportFiles(dir, runner.test.ExecutionConfig.External)
+			for _, file := range files {
+				_ = os.Remove(file)
+			}
+		}
+	}
+	return runner.TestRunner.Run(timeoutCtx, env, writer)
+}
+
+// NewExternalRunner - creates a runner of external test framework, run script is executed like a shell test and writes
+// JUnit or TAP reports into ARTIFACTS_DIR.
+func NewExternalRunner(ids string, test *model.TestEntry) TestRunner {
+	runner := NewShellTestRunner(ids, test)
+	if test.ExecutionConfig.Container != nil {
+		runner = NewContainerRunner(ids, test)
+	}
+	return &externalRunner{
+		TestRunner: runner,
+		test:       test,
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runners
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/networkservicemesh/cloudtest/pkg/ginkgo"
+	"github.com/networkservicemesh/cloudtest/pkg/model"
+	"github.com/networkservicemesh/cloudtest/pkg/shell"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+type ginkgoRunner struct {
+	test   *model.TestEntry
+	binary string
+	args   []string
+	envMgr shell.EnvironmentManager
+}
+
+// Run - runs focused specs and writes JSON report of ginkgo into artifacts directory, path of report is written into
+// output, so specs could be reported separately for every execution.
+func (runner *ginkgoRunner) Run(timeoutCtx context.Context, env []string, writer *bufio.Writer) error {
+	cmdEnv := append(append(os.Environ(), runner.envMgr.GetProcessedEnv()...), env...)
+	reportDir := os.TempDir()
+	for _, envVar := range cmdEnv {
+		if key, value, err := utils.ParseVariable(envVar); err == nil && key == artifactsDirEnv && value != "" {
+			reportDir = value
+		}
+	}
+	reportFile, err := filepath.Abs(filepath.Join(reportDir, fmt.Sprintf("ginkgo-report-%d.json", time.Now().UnixNano())))
+	if err != nil {
+		return err
+	}
+
+	args := append(append([]string{}, runner.args...), "--json-report", reportFile, ".")
+	cmd := exec.CommandContext(timeoutCtx, runner.binary, args...)
+	cmd.Dir = runner.test.ExecutionConfig.PackageRoot
+	cmd.Env = cmdEnv
+	cmd.Stdout = writer
+	cmd.Stderr = writer
+	err = cmd.Run()
+
+	if info, statErr := os.Stat(reportFile); statErr == nil && info.Size() > 0 {
+		_, _ = fmt.Fprintf(writer, "\n%s%s\n", ginkgo.ReportPrefix, reportFile)
+	}
+	_ = writer.Flush()
+	return err
+}
+
+func (runner *ginkgoRunner) GetCmdLine() string {
+	cmdLine := []string{runner.binary}
+	for _, arg := range runner.args {
+		if strings.ContainsAny(arg, " \t") {
+			arg = strconv.Quote(arg)
+		}
+		cmdLine = append(cmdLine, arg)
+	}
+	return strings.Join(append(cmdLine, "."), " ")
+}
+
+// NewGinkgoRunner - creates a runner of Ginkgo suite test, only specs of test suite are focused.
+func NewGinkgoRunner(ids string, test *model.TestEntry, timeout time.Duration) TestRunner {
+	args := append([]string{"--timeout", timeout.String()}, ginkgo.Args(test.ExecutionConfig)...)
+	args = append(args, "--focus", ginkgo.Focus(test.Suite.Tests))
+
+	envMgr := shell.NewEnvironmentManager()
+	_ = envMgr.ProcessEnvironment(ids, "ginkgo", os.TempDir(), test.ExecutionConfig.Env, map[string]string{})
+	return &ginkgoRunner{
+		test:   test,
+		binary: ginkgo.Binary(test.ExecutionConfig),
+		args:   args,
+		envMgr: envMgr,
+	}
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/networkservicemesh/cloudtest/pkg/model"
 	"github.com/networkservicemesh/cloudtest/pkg/shell"
+	"github.com/networkservicemesh/cloudtest/pkg/suites/parse"
 	"github.com/networkservicemesh/cloudtest/pkg/utils"
 )
 
@@ -39,8 +40,18 @@ type goTestRunner struct {
 func (runner *goTestRunner) Run(timeoutCtx context.Context, env []string, writer *bufio.Writer) error {
 	logger := func(s string) {}
 	cmdEnv := append(runner.envMgr.GetProcessedEnv(), env...)
+	if runner.test.Binary == "" {
+		_, err := utils.RunCommand(timeoutCtx, runner.cmdLine, runner.test.ExecutionConfig.PackageRoot,
+			logger, writer, cmdEnv, nil, false)
+		return err
+	}
+	// Verbose output of binary is converted into JSON events, like go test -json does.
+	converter := parse.NewConverter(flushWriter{writer})
 	_, err := utils.RunCommand(timeoutCtx, runner.cmdLine, runner.test.ExecutionConfig.PackageRoot,
-		logger, writer, cmdEnv, nil, false)
+		logger, bufio.NewWriter(converter), cmdEnv, nil, false)
+	if closeErr := converter.Exit(err); err == nil {
+		err = closeErr
+	}
 	return err
 }
 
@@ -48,10 +59,15 @@ func (runner *goTestRunner) GetCmdLine() string {
 	return runner.cmdLine
 }
 
-// NewGoTestRunner - creates go test runner
+// NewGoTestRunner - creates go test runner, test output is written in JSON format to report subtests separately.
+// If test is compiled, binary is executed directly and its verbose output is converted into JSON.
 func NewGoTestRunner(ids string, test *model.TestEntry, timeout time.Duration) TestRunner {
-	cmdLine := fmt.Sprintf(`go test . -test.timeout %v -count 1 --run "^(%s)$\\z" --tags "%s" --test.v`,
+	cmdLine := fmt.Sprintf(`go test . -test.timeout %v -count 1 -json --run "^(%s)$\\z" --tags "%s" --test.v`,
 		timeout, test.Name, test.Tags)
+	if test.Binary != "" {
+		cmdLine = fmt.Sprintf(`"%s" -test.timeout %v -test.count 1 -test.run "^(%s)$\\z" -test.v`,
+			test.Binary, timeout, test.Name)
+	}
 
 	envMgr := shell.NewEnvironmentManager()
 	_ = envMgr.ProcessEnvironment(ids, "gotest", os.TempDir(), test.ExecutionConfig.Env, map[string]string{})
@@ -0,0 +1,79 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runners
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/execmanager"
+	"github.com/networkservicemesh/cloudtest/pkg/model"
+)
+
+// TestFinder - returns tests of execution, they are executed by runner of the execution kind.
+type TestFinder func(execution *config.Execution, manager execmanager.ExecutionManager) ([]*model.TestEntry, error)
+
+// RunnerFunction - creates a runner of test for cluster task ids, timeout is a timeout of test.
+type RunnerFunction func(ids string, test *model.TestEntry, timeout time.Duration) TestRunner
+
+// Runner - describes how executions of registered kind are executed.
+type Runner struct {
+	// FindTests - finds tests of execution, if not set execution is treated as one test named after execution.
+	FindTests TestFinder
+	// NewRunner - creates a runner of found test.
+	NewRunner RunnerFunction
+}
+
+// builtinKinds - execution kinds supported by cloudtest itself.
+var builtinKinds = []string{"", "gotest", "shell", "ginkgo", "external"}
+
+var registry = struct {
+	sync.RWMutex
+	runners map[string]Runner
+}{
+	runners: map[string]Runner{},
+}
+
+// Register - makes executions of passed kind to be executed with runner.
+// It panics if kind is built-in or already registered, or runner has no NewRunner function.
+func Register(kind string, runner Runner) {
+	if runner.NewRunner == nil {
+		panic(errors.Errorf("runner of execution kind %q has no NewRunner function", kind))
+	}
+	for _, builtin := range builtinKinds {
+		if kind == builtin {
+			panic(errors.Errorf("execution kind %q is built-in", kind))
+		}
+	}
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.runners[kind]; ok {
+		panic(errors.Errorf("runner of execution kind %q is already registered", kind))
+	}
+	registry.runners[kind] = runner
+}
+
+// Lookup - returns runner registered for execution kind.
+func Lookup(kind string) (Runner, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	runner, ok := registry.runners[kind]
+	return runner, ok
+}
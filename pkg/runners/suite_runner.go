@@ -20,6 +20,7 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
@@ -28,6 +29,7 @@ import (
 
 	"github.com/networkservicemesh/cloudtest/pkg/model"
 	"github.com/networkservicemesh/cloudtest/pkg/shell"
+	"github.com/networkservicemesh/cloudtest/pkg/suites/parse"
 )
 
 type SuiteRunner struct {
@@ -38,13 +40,25 @@ type SuiteRunner struct {
 
 func (s *SuiteRunner) Run(ctx context.Context, envs []string, writer *bufio.Writer) error {
 	envs = append(append(envs, s.envManager.GetProcessedEnv()...), os.Environ()...)
+	var out io.Writer = writer
+	var converter *parse.Converter
+	if s.test.Binary != "" {
+		// Verbose output of binary is converted into JSON events, like go test -json does.
+		converter = parse.NewConverter(writer)
+		out = converter
+	}
 	err := exechelper.Run(s.cmd,
-		exechelper.WithStdout(writer),
-		exechelper.WithStderr(writer),
+		exechelper.WithStdout(out),
+		exechelper.WithStderr(out),
 		exechelper.WithContext(ctx),
 		exechelper.WithDir(s.test.ExecutionConfig.PackageRoot),
 		exechelper.WithEnvirons(envs...),
 	)
+	if converter != nil {
+		if closeErr := converter.Exit(err); err == nil {
+			err = closeErr
+		}
+	}
 	return err
 }
 
@@ -58,6 +72,10 @@ func NewSuiteRunner(ids string, test *model.TestEntry, timeout time.Duration) *S
 	pattern := strings.Join(test.Suite.Tests, "|")
 	cmdLine := fmt.Sprintf(`go test . -test.timeout %v -count 1 -json --run "^(%s)$\\z" --tags "%s" --test.v --testify.m="%v"`,
 		timeout, test.Suite.Name, test.Tags, pattern)
+	if test.Binary != "" {
+		cmdLine = fmt.Sprintf(`"%s" -test.timeout %v -test.count 1 -test.run "^(%s)$\\z" -test.v -testify.m="%v"`,
+			test.Binary, timeout, test.Suite.Name, pattern)
+	}
 	envMgr := shell.NewEnvironmentManager()
 	_ = envMgr.ProcessEnvironment(ids, "gotest", os.TempDir(), test.ExecutionConfig.Env, map[string]string{})
 	return &SuiteRunner{
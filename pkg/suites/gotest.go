@@ -0,0 +1,190 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package suites
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/networkservicemesh/cloudtest/pkg/execmanager"
+	"github.com/networkservicemesh/cloudtest/pkg/model"
+	"github.com/networkservicemesh/cloudtest/pkg/suites/parse"
+	"github.com/networkservicemesh/cloudtest/pkg/suites/testentry"
+)
+
+// SplitTest returns list of model.TestEntry for the go test and its subtests, read from go test JSON output of every
+// test execution. The first entry is the test itself, package output like build errors is added to it.
+func SplitTest(
+	test *model.TestEntry,
+	manager execmanager.ExecutionManager,
+	clusterTaskID string,
+) (tests []*model.TestEntry, err error) {
+	s := &testSplitter{
+		test:     test,
+		builders: map[string]*testentry.Builder{},
+		newBuilder: func(name string) *testentry.Builder {
+			return testentry.NewBuilder(name, test, manager, clusterTaskID)
+		},
+	}
+	s.builder(test.Name)
+
+	for _, execution := range test.Executions {
+		if err = s.splitExecution(execution); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, name := range s.names {
+		tests = append(tests, s.builders[name].Build())
+	}
+	return tests, nil
+}
+
+type testSplitter struct {
+	test       *model.TestEntry
+	names      []string
+	builders   map[string]*testentry.Builder
+	newBuilder func(name string) *testentry.Builder
+}
+
+func (s *testSplitter) builder(name string) *testentry.Builder {
+	builder, ok := s.builders[name]
+	if !ok {
+		builder = s.newBuilder(name)
+		s.builders[name] = builder
+		s.names = append(s.names, name)
+	}
+	return builder
+}
+
+func (s *testSplitter) splitExecution(execution model.TestEntryExecution) error {
+	file, err := os.Open(execution.OutputFile)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+
+	var packageOutput []*parse.TestEvent
+	var rootStatus *parse.TestEvent
+	run := make(map[string]bool)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		event := parseLine(scanner.Text())
+		name := event.Test
+		if name != s.test.Name && !strings.HasPrefix(name, s.test.Name+"/") {
+			// Package output, like build errors, or output of cloudtest and scripts, like on-fail.
+			if event.Action == "output" {
+				packageOutput = append(packageOutput, event)
+			}
+			continue
+		}
+		if event.Action == "run" {
+			run[name] = true
+		}
+		if !run[name] {
+			continue
+		}
+		if name == s.test.Name && (event.Action == "pass" || event.Action == "fail" || event.Action == "skip") {
+			// Test status is processed after all package output is added to test.
+			rootStatus = event
+			continue
+		}
+		if err = event.Process(s.builder(name)); err != nil {
+			return err
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return err
+	}
+
+	root := s.builders[s.test.Name]
+	if !run[s.test.Name] {
+		// Test is not started at all, so package output and execution status are stored for test itself.
+		if err = s.processNotStarted(root, execution, packageOutput); err != nil {
+			return err
+		}
+		s.addDiagnostics(execution, run)
+		return nil
+	}
+	for _, event := range packageOutput {
+		if err = root.ProcessOutputEvent(event); err != nil {
+			return err
+		}
+	}
+	if rootStatus != nil {
+		if err = rootStatus.Process(root); err != nil {
+			return err
+		}
+	}
+	// Tests without final status are interrupted by timeout.
+	end := &parse.TestEvent{Time: s.test.Started.Add(s.test.Duration)}
+	for name := range run {
+		if err = s.builders[name].ProcessExecutionEnd(end); err != nil {
+			return err
+		}
+	}
+	s.addDiagnostics(execution, run)
+	return nil
+}
+
+// addDiagnostics - links diagnostics collected on failure of execution to the test and all its subtests run by it.
+func (s *testSplitter) addDiagnostics(execution model.TestEntryExecution, run map[string]bool) {
+	if len(execution.Diagnostics) == 0 {
+		return
+	}
+	s.builders[s.test.Name].AddDiagnostics(execution.Diagnostics)
+	for name := range run {
+		if name != s.test.Name {
+			s.builders[name].AddDiagnostics(execution.Diagnostics)
+		}
+	}
+}
+
+func (s *testSplitter) processNotStarted(root *testentry.Builder, execution model.TestEntryExecution, packageOutput []*parse.TestEvent) error {
+	event := &parse.TestEvent{Time: s.test.Started}
+	if err := root.ProcessRunEvent(event); err != nil {
+		return err
+	}
+	for _, output := range packageOutput {
+		if err := root.ProcessOutputEvent(output); err != nil {
+			return err
+		}
+	}
+	if execution.Status == model.StatusSuccess {
+		return root.ProcessPassEvent(event)
+	}
+	return root.ProcessFailEvent(event)
+}
+
+// OutputLine returns output of go test JSON event line without trailing new line, lines are not produced by test2json
+// are returned as is. Other events have no output, so empty string is returned for them.
+func OutputLine(line string) string {
+	return strings.TrimSuffix(parseLine(strings.TrimSuffix(line, "\n")).Output, "\n")
+}
+
+// parseLine returns go test JSON event of output line, lines are not produced by test2json are treated as output.
+func parseLine(line string) *parse.TestEvent {
+	event := &parse.TestEvent{}
+	if strings.HasPrefix(line, "{") && json.Unmarshal([]byte(line), event) == nil && event.Action != "" {
+		return event
+	}
+	return &parse.TestEvent{Action: "output", Output: line + "\n"}
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var reportRegexp = regexp.MustCompile(`^\s*--- (PASS|FAIL|SKIP): (.+) \(([0-9.]+)s\)\s*$`)
+
+// Converter - converts verbose output of test binary into go test JSON events written line by line, like
+// go tool test2json does. Output lines are assigned to the test started or reported last.
+type Converter struct {
+	writer   io.Writer
+	buf      []byte
+	current  string
+	finished bool // Result of binary is printed.
+	mu       sync.Mutex
+}
+
+// NewConverter - creates a converter writing JSON events into writer.
+func NewConverter(writer io.Writer) *Converter {
+	return &Converter{writer: writer}
+}
+
+// Write - converts all complete lines of output, the rest is kept until the next write or Exit.
+func (c *Converter) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.buf = append(c.buf, p...)
+	for {
+		idx := bytes.IndexByte(c.buf, '\n')
+		if idx < 0 {
+			return len(p), nil
+		}
+		line := string(c.buf[:idx+1])
+		c.buf = c.buf[idx+1:]
+		if err := c.convertLine(line); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// Exit - converts the rest of output not terminated by new line. If binary is exited with error without printing
+// a result, package failure is reported, like go test does for crashed binaries.
+func (c *Converter) Exit(exitErr error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.buf) != 0 {
+		line := string(c.buf) + "\n"
+		c.buf = nil
+		if err := c.convertLine(line); err != nil {
+			return err
+		}
+	}
+	if exitErr == nil || c.finished {
+		return nil
+	}
+	c.current = ""
+	return c.write(&TestEvent{Action: "output", Output: "FAIL\n"}, &TestEvent{Action: "fail"})
+}
+
+func (c *Converter) convertLine(line string) error {
+	trimmed := strings.TrimSpace(line)
+	switch {
+	case strings.HasPrefix(trimmed, "=== RUN "):
+		c.current = strings.TrimSpace(strings.TrimPrefix(trimmed, "=== RUN "))
+		return c.write(&TestEvent{Action: "run", Test: c.current}, &TestEvent{Action: "output", Test: c.current, Output: line})
+	case strings.HasPrefix(trimmed, "=== PAUSE "):
+		name := strings.TrimSpace(strings.TrimPrefix(trimmed, "=== PAUSE "))
+		c.current = ""
+		return c.write(&TestEvent{Action: "output", Test: name, Output: line}, &TestEvent{Action: "pause", Test: name})
+	case strings.HasPrefix(trimmed, "=== CONT "):
+		c.current = strings.TrimSpace(strings.TrimPrefix(trimmed, "=== CONT "))
+		return c.write(&TestEvent{Action: "cont", Test: c.current}, &TestEvent{Action: "output", Test: c.current, Output: line})
+	case trimmed == "PASS" || trimmed == "FAIL":
+		c.current = ""
+		c.finished = true
+		return c.write(&TestEvent{Action: "output", Output: line}, &TestEvent{Action: strings.ToLower(trimmed)})
+	}
+	if match := reportRegexp.FindStringSubmatch(line); match != nil {
+		c.current = match[2]
+		elapsed, _ := strconv.ParseFloat(match[3], 64)
+		return c.write(
+			&TestEvent{Action: "output", Test: c.current, Output: line},
+			&TestEvent{Action: strings.ToLower(match[1]), Test: c.current, Elapsed: elapsed},
+		)
+	}
+	return c.write(&TestEvent{Action: "output", Test: c.current, Output: line})
+}
+
+func (c *Converter) write(events ...*TestEvent) error {
+	for _, event := range events {
+		event.Time = time.Now()
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		if _, err = c.writer.Write(append(data, '\n')); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cloudtest/pkg/suites/parse"
+)
+
+const verboseOutput = `=== RUN   TestSuite
+=== RUN   TestSuite/TestPass
+    suite_test.go:10: passed
+=== RUN   TestSuite/TestFail
+    suite_test.go:14: failed
+--- FAIL: TestSuite (0.02s)
+    --- PASS: TestSuite/TestPass (0.00s)
+    --- FAIL: TestSuite/TestFail (0.01s)
+FAIL
+`
+
+func TestConverter(t *testing.T) {
+	out := &strings.Builder{}
+	converter := parse.NewConverter(out)
+	// Lines could be split between writes.
+	_, err := converter.Write([]byte(verboseOutput[:30]))
+	require.NoError(t, err)
+	_, err = converter.Write([]byte(verboseOutput[30:]))
+	require.NoError(t, err)
+	_, err = converter.Write([]byte("exit status 1"))
+	require.NoError(t, err)
+	require.NoError(t, converter.Exit(errors.New("exit status 1")))
+
+	var actions []string
+	outputs := map[string]string{}
+	for event := range parse.Events(strings.NewReader(out.String())) {
+		require.NoError(t, event.Err)
+		if event.Action == "output" {
+			outputs[event.Test] += event.Output
+			continue
+		}
+		actions = append(actions, event.Action+" "+event.Test)
+	}
+
+	require.Equal(t, []string{
+		"run TestSuite",
+		"run TestSuite/TestPass",
+		"run TestSuite/TestFail",
+		"fail TestSuite",
+		"pass TestSuite/TestPass",
+		"fail TestSuite/TestFail",
+		"fail ",
+	}, actions)
+	require.Contains(t, outputs["TestSuite/TestPass"], "suite_test.go:10: passed\n")
+	require.Contains(t, outputs["TestSuite/TestFail"], "suite_test.go:14: failed\n")
+	require.Equal(t, "FAIL\nexit status 1\n", outputs[""])
+}
+
+func TestConverterCrash(t *testing.T) {
+	out := &strings.Builder{}
+	converter := parse.NewConverter(out)
+	_, err := converter.Write([]byte("=== RUN   TestSuite\nlevel=fatal\n"))
+	require.NoError(t, err)
+	require.NoError(t, converter.Exit(errors.New("exit status 1")))
+
+	var actions []string
+	for event := range parse.Events(strings.NewReader(out.String())) {
+		require.NoError(t, event.Err)
+		if event.Action != "output" {
+			actions = append(actions, event.Action+" "+event.Test)
+		}
+	}
+	// Package is failed, since binary has not printed result.
+	require.Equal(t, []string{"run TestSuite", "fail "}, actions)
+}
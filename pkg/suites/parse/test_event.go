@@ -50,6 +50,8 @@ func (e *TestEvent) Process(processor TestEventProcessor) error {
 		return processor.ProcessPassEvent(e)
 	case "fail":
 		return processor.ProcessFailEvent(e)
+	case "start", "pause", "cont":
+		return nil
 	case "bench", "output":
 		return processor.ProcessOutputEvent(e)
 	case "skip":
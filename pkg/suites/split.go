@@ -27,7 +27,8 @@ import (
 )
 
 const (
-	setupSuite = "SetupSuite"
+	// SetupSuite is a name of test entry used for go suite setup output
+	SetupSuite = "SetupSuite"
 )
 
 // SkipSuite returns list of model.TestEntry for the skipped go suite
@@ -40,6 +41,7 @@ func SkipSuite(suite *model.TestEntry) (tests []*model.TestEntry) {
 			RunScript:       suite.RunScript,
 			Kind:            model.GoTestKind,
 			Status:          suite.Status,
+			SkipMessage:     suite.SkipMessage,
 		})
 	}
 
@@ -53,7 +55,7 @@ func SplitSuite(
 	clusterTaskID string,
 ) (tests []*model.TestEntry, err error) {
 	suiteTests := make([]string, len(suite.Suite.Tests)+1)
-	suiteTests[0] = setupSuite
+	suiteTests[0] = SetupSuite
 	copy(suiteTests[1:], suite.Suite.Tests)
 
 	builders := make(map[string]*testentry.Builder)
@@ -65,8 +67,8 @@ func SplitSuite(
 		return nil, err
 	}
 
-	setup := builders[setupSuite].Build()
-	delete(builders, setupSuite)
+	setup := builders[SetupSuite].Build()
+	delete(builders, SetupSuite)
 
 	allSkip := true
 	for _, builder := range builders {
@@ -82,6 +84,39 @@ func SplitSuite(
 	return tests, nil
 }
 
+// FailedTests - returns names of suite tests failed in the last execution of the suite, SetupSuite is returned if
+// suite is failed with no failed tests.
+func FailedTests(suite *model.TestEntry) ([]string, error) {
+	if len(suite.Executions) == 0 {
+		return nil, nil
+	}
+	file, err := os.Open(suite.Executions[len(suite.Executions)-1].OutputFile)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	var failed []string
+	suiteFailed := false
+	for event := range parse.Events(file) {
+		if event.Err != nil {
+			return nil, event.Err
+		}
+		if event.Action != "fail" {
+			continue
+		}
+		if testName := event.TestName(); testName != "" {
+			failed = append(failed, testName)
+		} else {
+			suiteFailed = true
+		}
+	}
+	if suiteFailed && len(failed) == 0 {
+		failed = append(failed, SetupSuite)
+	}
+	return failed, nil
+}
+
 func splitExecutions(suite *model.TestEntry, builders map[string]*testentry.Builder) error {
 	for _, execution := range suite.Executions {
 		file, err := os.Open(execution.OutputFile)
@@ -98,7 +133,7 @@ func splitExecutions(suite *model.TestEntry, builders map[string]*testentry.Buil
 			var testName string
 			switch {
 			case event.Test == "" || strings.HasPrefix(suite.Name, event.Test):
-				testName = setupSuite
+				testName = SetupSuite
 			default:
 				testName = event.TestName()
 			}
@@ -20,6 +20,7 @@ package testentry
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/networkservicemesh/cloudtest/pkg/execmanager"
 	"github.com/networkservicemesh/cloudtest/pkg/model"
@@ -33,6 +34,7 @@ type Builder struct {
 	suiteEntry    *model.TestEntry
 	testEntry     *model.TestEntry
 	file          *os.File
+	output        []string // Output of current execution, used to find skip reason.
 }
 
 // NewBuilder returns a new Builder
@@ -57,12 +59,18 @@ func NewBuilder(
 
 // Build finishes building and returns a new model.TestEntry
 func (b *Builder) Build() *model.TestEntry {
+	_ = b.ProcessExecutionEnd(&parse.TestEvent{
+		Time: b.suiteEntry.Started.Add(b.suiteEntry.Duration),
+	})
+	return b.testEntry
+}
+
+// ProcessExecutionEnd finishes current test execution if no status event is received for it, test is treated as timed out
+func (b *Builder) ProcessExecutionEnd(testEvent *parse.TestEvent) error {
 	if b.file != nil {
-		_ = b.processStatusEvent(&parse.TestEvent{
-			Time: b.suiteEntry.Started.Add(b.suiteEntry.Duration),
-		}, model.StatusTimeout)
+		return b.processStatusEvent(testEvent, model.StatusTimeout)
 	}
-	return b.testEntry
+	return nil
 }
 
 // ProcessRunEvent processes "run" parse.TestEvent
@@ -71,7 +79,8 @@ func (b *Builder) ProcessRunEvent(testEvent *parse.TestEvent) (err error) {
 		b.testEntry.Started = testEvent.Time
 	}
 
-	fileName := fmt.Sprintf("%s-%s", b.suiteEntry.Name, b.testEntry.Name)
+	// Subtest names contain '/', so it is replaced to keep file in category folder.
+	fileName := strings.ReplaceAll(fmt.Sprintf("%s-%s", b.suiteEntry.Name, b.testEntry.Name), "/", "_")
 	if fileName, b.file, err = b.manager.OpenFileTest(b.clusterTaskID, fileName, "run"); err != nil {
 		return err
 	}
@@ -80,10 +89,18 @@ func (b *Builder) ProcessRunEvent(testEvent *parse.TestEvent) (err error) {
 		OutputFile: fileName,
 		Retry:      len(b.testEntry.Executions) + 1,
 	})
+	b.output = nil
 
 	return nil
 }
 
+// AddDiagnostics links diagnostics directories to the last execution of test
+func (b *Builder) AddDiagnostics(dirs []string) {
+	if count := len(b.testEntry.Executions); count > 0 {
+		b.testEntry.Executions[count-1].Diagnostics = dirs
+	}
+}
+
 // ProcessPassEvent processes "pass" parse.TestEvent
 func (b *Builder) ProcessPassEvent(testEvent *parse.TestEvent) error {
 	return b.processStatusEvent(testEvent, model.StatusSuccess)
@@ -97,15 +114,19 @@ func (b *Builder) ProcessFailEvent(testEvent *parse.TestEvent) error {
 // ProcessOutputEvent processes "output" parse.TestEvent
 func (b *Builder) ProcessOutputEvent(testEvent *parse.TestEvent) error {
 	if b.file != nil {
+		b.output = append(b.output, testEvent.Output)
 		_, err := b.file.WriteString(testEvent.Output)
 		return err
 	}
 	return nil
 }
 
-// ProcessSkipEvent processes "skip" parse.TestEvent
+// ProcessSkipEvent processes "skip" parse.TestEvent, if event has no output skip reason is taken from test output
 func (b *Builder) ProcessSkipEvent(testEvent *parse.TestEvent) error {
 	b.testEntry.SkipMessage = testEvent.Output
+	if b.testEntry.SkipMessage == "" {
+		b.testEntry.SkipMessage = skipReason(b.output)
+	}
 
 	return b.processStatusEvent(testEvent, model.StatusSkipped)
 }
@@ -121,3 +142,16 @@ func (b *Builder) processStatusEvent(testEvent *parse.TestEvent, status model.St
 
 	return nil
 }
+
+// skipReason returns test output lines written by t.Skip and t.Log, go test service lines are ignored
+func skipReason(output []string) string {
+	var lines []string
+	for _, line := range output {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "=== ") || strings.HasPrefix(trimmed, "--- ") {
+			continue
+		}
+		lines = append(lines, trimmed)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -17,6 +17,7 @@
 package tests
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
 	"testing"
@@ -49,7 +50,7 @@ func TestClusterInstancesFailed(t *testing.T) {
 
 	testConfig.Reporting.JUnitReportFile = JunitReport
 
-	report, err := commands.PerformTesting(testConfig, &TestValidationFactory{}, &commands.Arguments{})
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
 	require.Contains(t, err.Error(), "there is failed tests 3")
 
 	require.NotNil(t, report)
@@ -92,7 +93,7 @@ func TestClusterInstancesFailedSpecificTestList(t *testing.T) {
 
 	testConfig.Reporting.JUnitReportFile = JunitReport
 
-	report, err := commands.PerformTesting(testConfig, &TestValidationFactory{}, &commands.Arguments{})
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
 	require.Contains(t, err.Error(), "there is failed tests 2")
 
 	require.NotNil(t, report)
@@ -124,7 +125,7 @@ func TestClusterInstancesOnFailGoRunner(t *testing.T) {
 
 	testConfig.Reporting.JUnitReportFile = JunitReport
 
-	report, err := commands.PerformTesting(testConfig, &TestValidationFactory{}, &commands.Arguments{})
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
 	require.Contains(t, err.Error(), "there is failed tests 3")
 
 	require.NotNil(t, report)
@@ -187,7 +188,7 @@ func TestClusterInstancesOnFailShellRunner(t *testing.T) {
 	})
 	testConfig.Reporting.JUnitReportFile = JunitReport
 
-	report, err := commands.PerformTesting(testConfig, &TestValidationFactory{}, &commands.Arguments{})
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
 	require.Contains(t, err.Error(), "there is failed tests 1")
 	foundFailTest := false
 
@@ -241,7 +242,7 @@ func TestClusterInstancesOnFailShellRunnerInterdomain(t *testing.T) {
 	logKeeper := utils.NewLogKeeper()
 	defer logKeeper.Stop()
 
-	report, err := commands.PerformTesting(testConfig, &TestValidationFactory{}, &commands.Arguments{})
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
 	require.NotNil(t, err)
 	require.Contains(t, err.Error(), "there is failed tests 1")
 	foundFailTest := false
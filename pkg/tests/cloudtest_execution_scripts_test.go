@@ -17,6 +17,7 @@
 package tests
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
 	"path"
@@ -74,7 +75,7 @@ func TestAfterWorksCorrectly(t *testing.T) {
 
 	testConfig.Reporting.JUnitReportFile = JunitReport
 
-	report, err := commands.PerformTesting(testConfig, &TestValidationFactory{}, &commands.Arguments{})
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
 	require.NoError(t, err)
 	require.NotNil(t, report)
 
@@ -128,7 +129,7 @@ func TestBeforeWorksCorrectly(t *testing.T) {
 
 	testConfig.Reporting.JUnitReportFile = JunitReport
 
-	report, err := commands.PerformTesting(testConfig, &TestValidationFactory{}, &commands.Arguments{})
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
 	require.NoError(t, err)
 	require.NotNil(t, report)
 
@@ -17,6 +17,7 @@
 package tests
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -54,7 +55,7 @@ func TestCloudtestProvidesArtifactsDirForEachTest(t *testing.T) {
 
 	testConfig.Reporting.JUnitReportFile = JunitReport
 
-	_, err = commands.PerformTesting(testConfig, &TestValidationFactory{}, &commands.Arguments{})
+	_, err = commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
 	require.NoError(t, err)
 	content, err := ioutil.ReadFile(filepath.Join(relativePath, testConfig.Providers[0].Name+"-1", "TestArtifacts", "artifact1.txt"))
 	require.NoError(t, err)
@@ -17,6 +17,7 @@
 package tests
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
 	"testing"
@@ -58,7 +59,7 @@ func TestCloudtestCanWorkWithSuites(t *testing.T) {
 
 	testConfig.Reporting.JUnitReportFile = JunitReport
 
-	report, _ := commands.PerformTesting(testConfig, &TestValidationFactory{}, &commands.Arguments{})
+	report, _ := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
 	require.NotNil(t, report)
 
 	var providerSuite *reporting.Suite
@@ -127,7 +128,7 @@ func TestCloudtestCanWorkWithSuitesSplit(t *testing.T) {
 
 	testConfig.Reporting.JUnitReportFile = JunitReport
 
-	report, err := commands.PerformTesting(testConfig, &TestValidationFactory{}, &commands.Arguments{})
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
 	require.NotNil(t, report)
 
 	var providerSuite *reporting.Suite
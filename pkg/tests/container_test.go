@@ -0,0 +1,148 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cloudtest/pkg/commands"
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/reporting"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+// fakeRuntime - logs arguments of container CLI and env file, executes command passed after image to run command.
+const fakeRuntime = `#!/bin/sh
+echo "$@" >> %[1]s
+if [ "$1" = "run" ]; then
+  while [ "$1" != "tools:latest" ]; do
+    if [ "$1" = "--env-file" ]; then cat "$2" >> %[2]s; fi
+    shift
+  done
+  shift
+  exec "$@"
+fi
+`
+
+func newContainerConfig(t *testing.T, tmpDir string) *config.ContainerConfig {
+	runtime := path.Join(tmpDir, "fake-docker")
+	content := []byte(fmt.Sprintf(fakeRuntime, path.Join(tmpDir, "runtime.log"), path.Join(tmpDir, "env.log")))
+	require.NoError(t, ioutil.WriteFile(runtime, content, 0700))
+	return &config.ContainerConfig{
+		Image:   "tools:latest",
+		Runtime: runtime,
+		Args:    []string{"--network=host"},
+	}
+}
+
+func TestContainerRunner(t *testing.T) {
+	testConfig := config.NewCloudTestConfig()
+	testConfig.Timeout = 300
+
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "cloud-test-container")
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+	testConfig.ConfigRoot = path.Join(tmpDir, "root")
+	createProvider(testConfig, "a_provider").Instances = 1
+
+	testConfig.Executions = append(testConfig.Executions, &config.Execution{
+		Name:    "in-container",
+		Timeout: 15,
+		Kind:    "shell",
+		Run:     "echo running in ${NAME}",
+		Env: []string{
+			"NAME=container",
+			"KUBECONFIG_EXTRA=" + path.Join(tmpDir, "a.yaml") + ":" + path.Join(tmpDir, "b.yaml"),
+		},
+		Container: newContainerConfig(t, tmpDir),
+	})
+
+	_, err = commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
+	require.NoError(t, err)
+
+	logs, err := filepath.Glob(path.Join(testConfig.ConfigRoot, "a_provider-1", "*-in-container-run.log"))
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	output, err := ioutil.ReadFile(logs[0])
+	require.NoError(t, err)
+	require.Contains(t, string(output), "running in container")
+
+	runtimeLog, err := ioutil.ReadFile(path.Join(tmpDir, "runtime.log"))
+	require.NoError(t, err)
+	root, err := filepath.Abs(".")
+	require.NoError(t, err)
+	kubeConfig, err := filepath.Abs("./.tests/config")
+	require.NoError(t, err)
+	args := string(runtimeLog)
+	require.Contains(t, args, "run --rm --name cloudtest-a_provider-1-")
+	require.Contains(t, args, "-v "+root+":"+root+" -w "+root)
+	require.Contains(t, args, "-v "+kubeConfig+":"+kubeConfig)
+	require.Contains(t, args, "-v "+path.Join(tmpDir, "a.yaml")+":"+path.Join(tmpDir, "a.yaml"))
+	require.Contains(t, args, "-v "+path.Join(tmpDir, "b.yaml")+":"+path.Join(tmpDir, "b.yaml"))
+	require.Contains(t, args, "-v "+path.Join(testConfig.ConfigRoot, "a_provider-1", "in-container"))
+	require.Contains(t, args, "--network=host tools:latest echo running in container")
+	require.NotContains(t, args, " -e ")
+
+	// Only variables of execution and cluster are forwarded, environment of host is not.
+	envLog, err := ioutil.ReadFile(path.Join(tmpDir, "env.log"))
+	require.NoError(t, err)
+	envVars := string(envLog)
+	require.Contains(t, envVars, "NAME=container\n")
+	require.Contains(t, envVars, "KUBECONFIG="+kubeConfig+"\n")
+	require.Contains(t, envVars, "ARTIFACTS_DIR=")
+	require.NotContains(t, envVars, "PATH=")
+	require.NotContains(t, envVars, "HOME=")
+}
+
+func TestContainerRunnerTimeout(t *testing.T) {
+	testConfig := config.NewCloudTestConfig()
+	testConfig.Timeout = 300
+
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "cloud-test-container")
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+	testConfig.ConfigRoot = path.Join(tmpDir, "root")
+	createProvider(testConfig, "a_provider").Instances = 1
+
+	testConfig.Executions = append(testConfig.Executions, &config.Execution{
+		Name:      "in-container",
+		Timeout:   2,
+		Kind:      "shell",
+		Run:       "sleep 30",
+		Container: newContainerConfig(t, tmpDir),
+	})
+
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
+	require.Error(t, err)
+
+	cases := map[string]*reporting.TestCase{}
+	collectTestCases(report.Suites[0], cases)
+	require.NotNil(t, cases["in-container"].Failure)
+
+	// Container is removed when task is timed out.
+	runtimeLog, err := ioutil.ReadFile(path.Join(tmpDir, "runtime.log"))
+	require.NoError(t, err)
+	require.Contains(t, string(runtimeLog), "rm -f cloudtest-a_provider-1-")
+}
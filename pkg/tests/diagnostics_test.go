@@ -0,0 +1,113 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cloudtest/pkg/commands"
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/k8s"
+	"github.com/networkservicemesh/cloudtest/pkg/reporting"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+type diagnosticsValidationFactory struct {
+	TestValidationFactory
+	options []*k8s.DiagnosticsOptions
+}
+
+type testDiagnosticsCollector struct {
+	factory  *diagnosticsValidationFactory
+	location string
+}
+
+func (c *testDiagnosticsCollector) CollectDiagnostics(_ context.Context, options *k8s.DiagnosticsOptions, dir string) error {
+	c.factory.Lock()
+	c.factory.options = append(c.factory.options, options)
+	c.factory.Unlock()
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path.Join(dir, "nodes.txt"), []byte(c.location), os.ModePerm)
+}
+
+func (f *diagnosticsValidationFactory) CreateDiagnosticsCollector(location string) (k8s.DiagnosticsCollector, error) {
+	return &testDiagnosticsCollector{factory: f, location: location}, nil
+}
+
+func TestDiagnostics(t *testing.T) {
+	testConfig := config.NewCloudTestConfig()
+	testConfig.Timeout = 300
+
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "cloud-test-diagnostics")
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+	testConfig.ConfigRoot = path.Join(tmpDir, "root")
+	testConfig.Reporting.JSONReportFile = "summary.json"
+	createProvider(testConfig, "a_provider").Instances = 1
+
+	testConfig.Executions = append(testConfig.Executions, &config.Execution{
+		Name:        "simple",
+		Timeout:     15,
+		PackageRoot: "./sample",
+		OnlyRun:     []string{"TestPass", "TestFail"},
+		Diagnostics: &config.DiagnosticsConfig{
+			Namespaces:    []string{"nsm-system"},
+			LabelSelector: "app=nsmgr",
+			LogLines:      100,
+		},
+	})
+
+	factory := &diagnosticsValidationFactory{}
+	report, err := commands.PerformTesting(context.Background(), testConfig, factory)
+	require.Error(t, err)
+
+	require.Len(t, factory.options, 1)
+	require.Equal(t, []string{"nsm-system"}, factory.options[0].Namespaces)
+	require.Equal(t, "app=nsmgr", factory.options[0].LabelSelector)
+	require.Equal(t, int64(100), factory.options[0].LogLines)
+
+	cases := map[string]*reporting.TestCase{}
+	collectTestCases(report.Suites[0], cases)
+	require.NotNil(t, cases["TestFail"].Failure)
+	require.Contains(t, cases["TestFail"].Failure.Contents, "Diagnostics: ")
+
+	content, err := ioutil.ReadFile(path.Join(testConfig.ConfigRoot, "summary.json"))
+	require.NoError(t, err)
+	summary := &reporting.Summary{}
+	require.NoError(t, json.Unmarshal(content, summary))
+	for _, test := range summary.Executions[0].Groups[0].Tests {
+		if test.Name != "TestFail" {
+			require.Empty(t, test.Executions[0].Diagnostics)
+			continue
+		}
+		require.Len(t, test.Executions[0].Diagnostics, 1)
+		dir := test.Executions[0].Diagnostics[0]
+		require.True(t, strings.HasPrefix(dir, test.ArtifactDirectories[0]))
+		require.True(t, strings.HasSuffix(dir, path.Join("diagnostics", "a_provider-1")))
+		require.FileExists(t, path.Join(dir, "nodes.txt"))
+	}
+}
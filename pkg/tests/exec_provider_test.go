@@ -0,0 +1,192 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cloudtest/pkg/commands"
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/execmanager"
+	"github.com/networkservicemesh/cloudtest/pkg/providers"
+	"github.com/networkservicemesh/cloudtest/pkg/providers/plugin"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+func newExecProviderConfig(t *testing.T, tmpDir string) *config.ClusterProviderConfig {
+	binary := path.Join(tmpDir, "fakeplugin")
+	out, err := exec.Command("go", "build", "-o", binary, "./fakeplugin").CombinedOutput()
+	require.NoError(t, err, string(out))
+	return &config.ClusterProviderConfig{
+		Name:       "plugin",
+		Kind:       "exec",
+		Instances:  1,
+		NodeCount:  1,
+		Enabled:    true,
+		Timeout:    100,
+		Env:        []string{"FAKE_PLUGIN_LOG=" + path.Join(tmpDir, "plugin.log")},
+		Parameters: map[string]string{},
+		Exec: &config.ExecConfig{
+			Binary: binary,
+		},
+	}
+}
+
+func TestExecProvider(t *testing.T) {
+	testConfig := config.NewCloudTestConfig()
+	testConfig.Timeout = 300
+
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "cloud-test-exec")
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+	testConfig.ConfigRoot = path.Join(tmpDir, "root")
+
+	testConfig.Providers = append(testConfig.Providers, newExecProviderConfig(t, tmpDir))
+	testConfig.Executions = append(testConfig.Executions, &config.Execution{
+		Name:        "simple",
+		Timeout:     15,
+		PackageRoot: "./sample",
+		OnlyRun:     []string{"TestPass"},
+	})
+
+	_, err = commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
+	require.NoError(t, err)
+
+	content, err := ioutil.ReadFile(path.Join(tmpDir, "plugin.log"))
+	require.NoError(t, err)
+	var lifecycle []string
+	cleanup := false
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		switch strings.Fields(line)[0] {
+		case plugin.OperationCleanup:
+			cleanup = true
+		case plugin.OperationValidate, plugin.OperationCheckAlive:
+		default:
+			lifecycle = append(lifecycle, line)
+		}
+	}
+	require.True(t, cleanup)
+	require.Equal(t, []string{
+		"create plugin-1 ",
+		"start plugin-1 ",
+		"get-config plugin-1 {\"cluster\":\"fake-plugin-1\"}",
+		"destroy plugin-1 {\"cluster\":\"fake-plugin-1\"}",
+	}, lifecycle)
+
+	// Plugin output is streamed into operation logs.
+	logs, err := filepath.Glob(path.Join(testConfig.ConfigRoot, "plugin-1", "*-start.log"))
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	startLog, err := ioutil.ReadFile(logs[0])
+	require.NoError(t, err)
+	require.Contains(t, string(startLog), "fake plugin: start plugin-1\n")
+	require.Contains(t, string(startLog), "fake plugin stderr: start\n")
+}
+
+func TestExecProviderValidation(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "cloud-test-exec")
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	cfg := newExecProviderConfig(t, tmpDir)
+	provider := plugin.NewPluginClusterProvider(path.Join(tmpDir, "root"))
+	require.NoError(t, provider.ValidateConfig(cfg))
+
+	// Environment variables are processed before plugin is called.
+	cfg.Env = []string{"FAKE_PLUGIN_DIR=" + tmpDir, "FAKE_PLUGIN_LOG=${FAKE_PLUGIN_DIR}/validate.log"}
+	require.NoError(t, provider.ValidateConfig(cfg))
+	require.FileExists(t, path.Join(tmpDir, "validate.log"))
+
+	cfg.Parameters["invalid"] = "true"
+	err = provider.ValidateConfig(cfg)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid configuration of plugin")
+
+	// Output could not be read, plugin is not blocked on writing the rest of it.
+	delete(cfg.Parameters, "invalid")
+	cfg.Parameters["long-output"] = "true"
+	err = provider.ValidateConfig(cfg)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to read output of plugin "+cfg.Exec.Binary+" validate")
+}
+
+func TestExecProviderRestart(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "cloud-test-exec")
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	cfg := newExecProviderConfig(t, tmpDir)
+	manager := execmanager.NewExecutionManager(path.Join(tmpDir, "results"))
+	provider := plugin.NewPluginClusterProvider(path.Join(tmpDir, "root"))
+
+	instance, err := provider.CreateCluster(cfg, &TestValidationFactory{}, manager, providers.InstanceOptions{})
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		_, err = instance.Start(time.Minute)
+		require.NoError(t, err)
+		require.NoError(t, instance.Destroy(time.Minute))
+	}
+
+	// Instance is created again after destroy with no state of destroyed cluster.
+	content, err := ioutil.ReadFile(path.Join(tmpDir, "plugin.log"))
+	require.NoError(t, err)
+	var lifecycle []string
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		if op := strings.Fields(line)[0]; op == plugin.OperationCreate || op == plugin.OperationDestroy {
+			lifecycle = append(lifecycle, line)
+		}
+	}
+	require.Equal(t, []string{
+		"create plugin-1 ",
+		"destroy plugin-1 {\"cluster\":\"fake-plugin-1\"}",
+		"create plugin-1 ",
+		"destroy plugin-1 {\"cluster\":\"fake-plugin-1\"}",
+	}, lifecycle)
+}
+
+func TestExecProviderPlan(t *testing.T) {
+	testConfig := config.NewCloudTestConfig()
+
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "cloud-test-exec")
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+	testConfig.ConfigRoot = path.Join(tmpDir, "root")
+
+	testConfig.Providers = append(testConfig.Providers, newExecProviderConfig(t, tmpDir))
+	testConfig.Executions = append(testConfig.Executions, &config.Execution{
+		Name:        "simple",
+		Timeout:     15,
+		PackageRoot: "./sample",
+		OnlyRun:     []string{"TestPass"},
+	})
+
+	_, err = commands.PerformPlan(testConfig, &TestValidationFactory{})
+	require.NoError(t, err)
+
+	// Plugin is not executed by planning.
+	require.NoFileExists(t, path.Join(tmpDir, "plugin.log"))
+}
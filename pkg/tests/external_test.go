@@ -0,0 +1,171 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cloudtest/pkg/commands"
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/reporting"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+const pytestReport = `<?xml version="1.0" encoding="utf-8"?>
+<testsuites>
+  <testsuite name="pytest" errors="0" failures="1" skipped="1" tests="3" time="0.1">
+    <testcase classname="tests.test_sample" name="test_pass" time="0.01"/>
+    <testcase classname="tests.test_sample" name="test_fail" time="0.02">
+      <failure message="assert 1 == 2">def test_fail(): assert 1 == 2</failure>
+      <system-out>fail output</system-out>
+    </testcase>
+    <testcase classname="tests.test_sample" name="test_skip" time="0">
+      <skipped message="not supported" type="pytest.skip"/>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+
+const batsReport = `1..3
+ok 1 pass
+not ok 2 fail
+# (in test file test/sample.bats, line 7)
+#   'false' failed
+ok 3 skip # skip not supported
+`
+
+func runExternalExecution(t *testing.T, execution *config.Execution, report string) (*reporting.JUnitFile, map[string]*reporting.TestCase) {
+	testConfig := config.NewCloudTestConfig()
+	testConfig.Timeout = 300
+
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "cloud-test-external")
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+	testConfig.ConfigRoot = path.Join(tmpDir, "root")
+	createProvider(testConfig, "a_provider").Instances = 1
+
+	reportFile := path.Join(tmpDir, "report")
+	require.NoError(t, ioutil.WriteFile(reportFile, []byte(report), os.ModePerm))
+	execution.Env = append(execution.Env, "REPORT="+reportFile)
+	testConfig.Executions = append(testConfig.Executions, execution)
+
+	junit, _ := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
+	require.NotNil(t, junit)
+
+	cases := map[string]*reporting.TestCase{}
+	collectTestCases(junit.Suites[0], cases)
+	return junit, cases
+}
+
+func TestExternalJUnitReport(t *testing.T) {
+	report, cases := runExternalExecution(t, &config.Execution{
+		Name:    "pytest",
+		Timeout: 15,
+		Kind:    "external",
+		Run:     "cp ${REPORT} ${ARTIFACTS_DIR}/report.xml",
+	}, pytestReport)
+
+	require.Equal(t, 3, report.Suites[0].Tests)
+	require.Equal(t, 1, report.Suites[0].Failures)
+	require.Len(t, cases, 3)
+
+	require.Nil(t, cases["test_pass"].Failure)
+	require.Equal(t, "tests.test_sample", cases["test_pass"].Classname)
+	require.Equal(t, "a_provider-1", cases["test_pass"].Cluster)
+
+	require.NotNil(t, cases["test_fail"].Failure)
+	require.Equal(t, "assert 1 == 2", cases["test_fail"].Failure.Message)
+	require.Contains(t, cases["test_fail"].Failure.Contents, "fail output")
+
+	require.NotNil(t, cases["test_skip"].SkipMessage)
+	require.Equal(t, "not supported", cases["test_skip"].SkipMessage.Message)
+}
+
+func TestExternalTAPReport(t *testing.T) {
+	report, cases := runExternalExecution(t, &config.Execution{
+		Name:    "bats",
+		Timeout: 15,
+		Kind:    "external",
+		Run:     "cp ${REPORT} ${ARTIFACTS_DIR}/sample.tap",
+		External: &config.ExternalConfig{
+			Format: "tap",
+		},
+	}, batsReport)
+
+	require.Equal(t, 3, report.Suites[0].Tests)
+	require.Equal(t, 1, report.Suites[0].Failures)
+	require.Len(t, cases, 3)
+
+	require.Nil(t, cases["pass"].Failure)
+	require.NotNil(t, cases["fail"].Failure)
+	require.Contains(t, cases["fail"].Failure.Contents, "'false' failed")
+	require.NotNil(t, cases["skip"].SkipMessage)
+	require.Equal(t, "not supported", cases["skip"].SkipMessage.Message)
+}
+
+func TestExternalFailedWithoutReport(t *testing.T) {
+	report, cases := runExternalExecution(t, &config.Execution{
+		Name:    "broken",
+		Timeout: 15,
+		Kind:    "external",
+		Run:     "cp ${REPORT} ${ARTIFACTS_DIR}/report.txt\nfalse",
+	}, pytestReport)
+
+	// Command is reported itself, since no reports are found.
+	require.Equal(t, 1, report.Suites[0].Tests)
+	require.Equal(t, 1, report.Suites[0].Failures)
+	require.Len(t, cases, 1)
+	require.NotNil(t, cases["broken"].Failure)
+}
+
+func TestExternalGlobalTimeout(t *testing.T) {
+	testConfig := config.NewCloudTestConfig()
+	testConfig.Timeout = 3
+
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "cloud-test-external")
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+	testConfig.ConfigRoot = path.Join(tmpDir, "root")
+	createProvider(testConfig, "a_provider").Instances = 1
+
+	testConfig.Executions = append(testConfig.Executions, &config.Execution{
+		Name:    "slow",
+		Timeout: 15,
+		Kind:    "external",
+		Run:     "sleep 10",
+	}, &config.Execution{
+		Name:    "pending",
+		Timeout: 15,
+		Kind:    "external",
+		Run:     "true",
+	})
+
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
+	require.Error(t, err)
+	require.Equal(t, "global timeout elapsed: 3 seconds", err.Error())
+	require.NotNil(t, report)
+
+	cases := map[string]*reporting.TestCase{}
+	collectTestCases(report.Suites[0], cases)
+	require.Contains(t, cases, "pending")
+}
@@ -17,6 +17,7 @@
 package tests
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -26,8 +27,9 @@ import (
 	"github.com/networkservicemesh/cloudtest/pkg/config"
 )
 
-func testConfig(failedTestLimit int, source *config.ExecutionSource) *config.CloudTestConfig {
+func testConfig(t *testing.T, failedTestLimit int, source *config.ExecutionSource) *config.CloudTestConfig {
 	testConfig := &config.CloudTestConfig{}
+	testConfig.ConfigRoot = t.TempDir()
 	testConfig.Timeout = 300
 	testConfig.FailedTestsLimit = failedTestLimit
 	createProvider(testConfig, "provider")
@@ -44,10 +46,10 @@ func testConfig(failedTestLimit int, source *config.ExecutionSource) *config.Clo
 
 func TestTerminateTestingWhenLimitReached(t *testing.T) {
 	failedTestLimit := 3
-	testConfig := testConfig(failedTestLimit, &config.ExecutionSource{
+	testConfig := testConfig(t, failedTestLimit, &config.ExecutionSource{
 		Tags: []string{"failed", "passed"},
 	})
-	report, err := commands.PerformTesting(testConfig, &TestValidationFactory{}, &commands.Arguments{})
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
 	require.Error(t, err)
 	require.Equal(t, fmt.Sprintf("Allowed limit for failed tests is reached: %d", failedTestLimit), err.Error())
 	require.NotNil(t, report)
@@ -56,10 +58,10 @@ func TestTerminateTestingWhenLimitReached(t *testing.T) {
 
 func TestTerminateTestingWhenLimitReachedFailedOnly(t *testing.T) {
 	failedTestLimit := 3
-	testConfig := testConfig(failedTestLimit, &config.ExecutionSource{
+	testConfig := testConfig(t, failedTestLimit, &config.ExecutionSource{
 		Tags: []string{"failed"},
 	})
-	report, err := commands.PerformTesting(testConfig, &TestValidationFactory{}, &commands.Arguments{})
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
 	require.Error(t, err)
 	require.Equal(t, fmt.Sprintf("Allowed limit for failed tests is reached: %d", failedTestLimit), err.Error())
 	require.NotNil(t, report)
@@ -68,10 +70,10 @@ func TestTerminateTestingWhenLimitReachedFailedOnly(t *testing.T) {
 
 func TestPassedTestsNotAffected(t *testing.T) {
 	failedTestLimit := 2
-	testConfig := testConfig(failedTestLimit, &config.ExecutionSource{
+	testConfig := testConfig(t, failedTestLimit, &config.ExecutionSource{
 		Tags: []string{"passed"},
 	})
-	report, err := commands.PerformTesting(testConfig, &TestValidationFactory{}, &commands.Arguments{})
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
 	require.NoError(t, err)
 	require.NotNil(t, report)
 	require.Equal(t, 0, report.Suites[0].Failures)
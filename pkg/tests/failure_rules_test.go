@@ -0,0 +1,99 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cloudtest/pkg/commands"
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/reporting"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+func TestFailureRules(t *testing.T) {
+	logKeeper := utils.NewLogKeeper()
+	defer logKeeper.Stop()
+
+	testConfig := config.NewCloudTestConfig()
+	testConfig.Timeout = 300
+
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "cloud-test-failure-rules")
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+	testConfig.ConfigRoot = path.Join(tmpDir, "root")
+	testConfig.Reporting.JSONReportFile = "summary.json"
+	createProvider(testConfig, "a_provider").Instances = 1
+	failedProvider := createProvider(testConfig, "b_provider")
+	failedProvider.Instances = 1
+	failedProvider.RetryCount = 0
+	failedProvider.Scripts["start"] = "echo Quota CPUS exceeded\nexit 2"
+
+	testConfig.FailureRules = []*config.FailureRule{
+		{Pattern: "Quota .* exceeded", Category: "QUOTA", Message: "Cloud quota is exceeded", Target: "cluster"},
+		{Pattern: "Failed test", Category: "TEST", Message: "Test assertion failed", Target: "test"},
+	}
+	testConfig.Executions = append(testConfig.Executions, &config.Execution{
+		Name:            "simple",
+		Timeout:         15,
+		PackageRoot:     "./sample",
+		ClusterSelector: []string{"a_provider"},
+		OnlyRun:         []string{"TestPass", "TestFail"},
+	}, &config.Execution{
+		Name:            "no-clusters",
+		Timeout:         15,
+		PackageRoot:     "./sample",
+		ClusterSelector: []string{"b_provider"},
+		OnlyRun:         []string{"TestPass"},
+	})
+
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
+	require.Error(t, err)
+
+	cases := map[string]*reporting.TestCase{}
+	for _, suite := range report.Suites {
+		collectTestCases(suite, cases)
+	}
+	require.NotNil(t, cases["TestFail"].Failure)
+	require.Equal(t, "TEST", cases["TestFail"].Failure.Type)
+	require.Equal(t, "Test assertion failed: TestFail", cases["TestFail"].Failure.Message)
+	require.NotNil(t, cases["Startup-b_provider-1"].Failure)
+	require.Equal(t, "QUOTA", cases["Startup-b_provider-1"].Failure.Type)
+	require.Equal(t, "Cloud quota is exceeded: b_provider-1", cases["Startup-b_provider-1"].Failure.Message)
+	require.Equal(t, 1, logKeeper.MessageCount("Failures by category:"))
+
+	content, err := ioutil.ReadFile(path.Join(testConfig.ConfigRoot, "summary.json"))
+	require.NoError(t, err)
+	summary := &reporting.Summary{}
+	require.NoError(t, json.Unmarshal(content, summary))
+	for _, test := range summary.Executions[0].Groups[0].Tests {
+		if test.Name == "TestFail" {
+			require.Equal(t, "TEST", test.FailureCategory)
+			require.Equal(t, "Test assertion failed", test.FailureMessage)
+		}
+	}
+	operation := summary.Clusters[1].Instances[0].Operations[0]
+	require.Equal(t, "failed", operation.Status)
+	require.Equal(t, "QUOTA", operation.FailureCategory)
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main - a fake ginkgo CLI used by tests of ginkgo executions.
+// Every invocation is appended into a file specified with FAKE_GINKGO_LOG environment variable.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/networkservicemesh/cloudtest/pkg/ginkgo"
+)
+
+type spec struct {
+	containers []string
+	text       string
+	labels     []string
+	state      string
+	message    string
+}
+
+var specs = []*spec{
+	{containers: []string{"Books"}, text: "can be loaded", state: "passed"},
+	{containers: []string{"Books"}, text: "can be saved", state: "failed", message: "Expected <bool>: false to be true"},
+	{containers: []string{"Books", "when empty"}, text: "has no pages", state: "skipped", message: "not implemented yet"},
+	{containers: []string{"Authors"}, text: "are slow", labels: []string{"slow"}, state: "passed"},
+}
+
+type arguments struct {
+	dryRun      bool
+	report      string
+	focus       []*regexp.Regexp
+	skip        []*regexp.Regexp
+	labelFilter string
+}
+
+func parseArguments(args []string) *arguments {
+	result := &arguments{}
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--dry-run":
+			result.dryRun = true
+		case "--json-report":
+			i++
+			result.report = args[i]
+		case "--focus":
+			i++
+			result.focus = append(result.focus, regexp.MustCompile(args[i]))
+		case "--skip":
+			i++
+			result.skip = append(result.skip, regexp.MustCompile(args[i]))
+		case "--label-filter":
+			i++
+			result.labelFilter = args[i]
+		case "--timeout", "--tags":
+			i++
+		}
+	}
+	return result
+}
+
+// matches - checks if spec is selected, only "!label" filters are supported.
+func (a *arguments) matches(s *spec) bool {
+	text := strings.Join(append(append([]string{}, s.containers...), s.text), " ")
+	for _, label := range s.labels {
+		if a.labelFilter == "!"+label {
+			return false
+		}
+	}
+	for _, skip := range a.skip {
+		if skip.MatchString(text) {
+			return false
+		}
+	}
+	if len(a.focus) == 0 {
+		return true
+	}
+	for _, focus := range a.focus {
+		if focus.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+func main() {
+	if err := appendLog(strings.Join(os.Args[1:], " ") + "\n"); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	args := parseArguments(os.Args[1:])
+	report := &ginkgo.Report{SuiteDescription: "Library Suite", SuiteSucceeded: true}
+	now := time.Now()
+	for _, s := range specs {
+		specReport := &ginkgo.SpecReport{
+			ContainerHierarchyTexts: s.containers,
+			LeafNodeType:            "It",
+			LeafNodeText:            s.text,
+			State:                   "skipped",
+			StartTime:               now,
+			EndTime:                 now,
+		}
+		if args.matches(s) {
+			specReport.State = "passed"
+			if !args.dryRun {
+				fmt.Printf("running %s\n", specReport.FullText())
+				specReport.State = s.state
+				specReport.EndTime = now.Add(time.Second)
+				specReport.Failure.Message = s.message
+				specReport.CapturedGinkgoWriterOutput = "output of " + specReport.FullText() + "\n"
+				report.SuiteSucceeded = report.SuiteSucceeded && s.state != "failed"
+			}
+		}
+		report.SpecReports = append(report.SpecReports, specReport)
+	}
+	content, _ := json.Marshal([]*ginkgo.Report{report})
+	if err := ioutil.WriteFile(args.report, content, 0600); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if !report.SuiteSucceeded {
+		os.Exit(1)
+	}
+}
+
+func appendLog(line string) error {
+	file, err := os.OpenFile(os.Getenv("FAKE_GINKGO_LOG"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+	_, err = file.WriteString(line)
+	return err
+}
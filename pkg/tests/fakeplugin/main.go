@@ -0,0 +1,97 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main - a fake provider plugin used by tests of exec provider.
+// Every operation is appended into a file specified with FAKE_PLUGIN_LOG environment variable.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/cloudtest/pkg/providers/plugin"
+)
+
+type state struct {
+	Cluster string `json:"cluster"`
+}
+
+func main() {
+	out := json.NewEncoder(os.Stdout)
+	request := &plugin.Request{}
+	if err := json.NewDecoder(os.Stdin).Decode(request); err != nil {
+		_ = out.Encode(&plugin.Message{Version: plugin.ProtocolVersion, Error: err.Error()})
+		os.Exit(1)
+	}
+	result, err := handle(request)
+	if err != nil {
+		_ = out.Encode(&plugin.Message{Version: plugin.ProtocolVersion, Error: err.Error()})
+		return
+	}
+	_ = out.Encode(&plugin.Message{Version: plugin.ProtocolVersion, Result: result})
+}
+
+func handle(request *plugin.Request) (*plugin.Result, error) {
+	if request.Version != plugin.ProtocolVersion {
+		return nil, errors.Errorf("unsupported version %v", request.Version)
+	}
+	if err := appendLog(fmt.Sprintf("%s %s %s\n", request.Operation, request.ID, string(request.State))); err != nil {
+		return nil, err
+	}
+	_ = json.NewEncoder(os.Stdout).Encode(&plugin.Message{Version: plugin.ProtocolVersion, Log: fmt.Sprintf("fake plugin: %s %s", request.Operation, request.ID)})
+	_, _ = fmt.Fprintf(os.Stderr, "fake plugin stderr: %s\n", request.Operation)
+
+	switch request.Operation {
+	case plugin.OperationValidate:
+		if request.Provider.Parameters["invalid"] == "true" {
+			return nil, errors.Errorf("invalid configuration of %v", request.Provider.Name)
+		}
+		if request.Provider.Parameters["long-output"] == "true" {
+			// A line longer than plugin client could read.
+			_, _ = fmt.Println(strings.Repeat("x", 17*1024*1024))
+		}
+	case plugin.OperationStart:
+		st, _ := json.Marshal(&state{Cluster: "fake-" + request.ID})
+		return &plugin.Result{State: st}, nil
+	case plugin.OperationGetConfig:
+		st := &state{}
+		if err := json.Unmarshal(request.State, st); err != nil || st.Cluster == "" {
+			return nil, errors.Errorf("cluster %v is not started", request.ID)
+		}
+		kubeConfig := path.Join(request.Root, "config")
+		if err := ioutil.WriteFile(kubeConfig, []byte(st.Cluster), 0600); err != nil {
+			return nil, err
+		}
+		return &plugin.Result{KubeConfig: kubeConfig}, nil
+	}
+	return &plugin.Result{}, nil
+}
+
+func appendLog(line string) error {
+	file, err := os.OpenFile(os.Getenv("FAKE_PLUGIN_LOG"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+	_, err = file.WriteString(line)
+	return err
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cloudtest/pkg/commands"
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/reporting"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+// flakyScript - fails with retest request on first execution and passes on next one.
+const flakyScript = `if [ -f "$1" ]; then echo passed; exit 0; fi
+touch "$1"
+echo "#Please_RETEST#"
+exit 1
+`
+
+func runFlakyTest(t *testing.T, policy string) (*reporting.TestCase, error) {
+	testConfig := config.NewCloudTestConfig()
+	testConfig.Timeout = 300
+	testConfig.RetestConfig = config.RetestConfig{
+		Patterns:     []string{"#Please_RETEST#"},
+		RestartCount: 2,
+	}
+	testConfig.Flaky.Policy = policy
+
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "cloud-test-flaky")
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+	testConfig.ConfigRoot = path.Join(tmpDir, "root")
+	createProvider(testConfig, "a_provider").Instances = 1
+
+	script := path.Join(tmpDir, "flaky.sh")
+	require.NoError(t, ioutil.WriteFile(script, []byte(flakyScript), os.ModePerm))
+	testConfig.Executions = append(testConfig.Executions, &config.Execution{
+		Name:    "flaky",
+		Timeout: 15,
+		Kind:    "shell",
+		Run:     "sh " + script + " " + path.Join(tmpDir, "marker"),
+	})
+
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
+	require.NotNil(t, report)
+	cases := map[string]*reporting.TestCase{}
+	collectTestCases(report.Suites[0], cases)
+	require.Contains(t, cases, "flaky")
+	return cases["flaky"], err
+}
+
+func TestFlakyTestPassed(t *testing.T) {
+	testCase, err := runFlakyTest(t, "")
+	require.NoError(t, err)
+	require.True(t, testCase.IsFlaky())
+	require.Nil(t, testCase.Failure)
+	require.Len(t, testCase.FlakyFailures, 1)
+	require.Contains(t, testCase.FlakyFailures[0].Message, "rerun-request")
+	require.Contains(t, testCase.FlakyFailures[0].Contents, "#Please_RETEST#")
+}
+
+func TestFlakyTestPolicyFail(t *testing.T) {
+	testCase, err := runFlakyTest(t, "fail")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "there is failed tests 1")
+	require.True(t, testCase.IsFlaky())
+	require.NotNil(t, testCase.Failure)
+	require.Equal(t, "FLAKY", testCase.Failure.Type)
+}
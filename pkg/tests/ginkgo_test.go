@@ -0,0 +1,116 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cloudtest/pkg/commands"
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/reporting"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+func collectTestCases(suite *reporting.Suite, cases map[string]*reporting.TestCase) {
+	for _, testCase := range suite.TestCases {
+		cases[testCase.Name] = testCase
+	}
+	for _, s := range suite.Suites {
+		collectTestCases(s, cases)
+	}
+}
+
+func TestGinkgoExecution(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "cloud-test-ginkgo")
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	binary := path.Join(tmpDir, "ginkgo")
+	out, err := exec.Command("go", "build", "-o", binary, "./fakeginkgo").CombinedOutput()
+	require.NoError(t, err, string(out))
+	logFile := path.Join(tmpDir, "ginkgo.log")
+	require.NoError(t, os.Setenv("FAKE_GINKGO_LOG", logFile))
+	defer func() { _ = os.Unsetenv("FAKE_GINKGO_LOG") }()
+
+	testConfig := config.NewCloudTestConfig()
+	testConfig.Timeout = 300
+	testConfig.ConfigRoot = path.Join(tmpDir, "root")
+	createProvider(testConfig, "a_provider")
+	testConfig.Executions = append(testConfig.Executions, &config.Execution{
+		Name:        "library",
+		Kind:        "ginkgo",
+		Timeout:     15,
+		PackageRoot: tmpDir,
+		Ginkgo: &config.GinkgoConfig{
+			Binary:      binary,
+			LabelFilter: "!slow",
+		},
+	})
+
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
+	require.Error(t, err)
+	require.Equal(t, "there is failed tests 1", err.Error())
+
+	rootSuite := report.Suites[0]
+	require.Equal(t, 3, rootSuite.Tests)
+	require.Equal(t, 1, rootSuite.Failures)
+
+	cases := map[string]*reporting.TestCase{}
+	collectTestCases(rootSuite, cases)
+	require.Len(t, cases, 3)
+	require.Nil(t, cases["Books can be loaded"].Failure)
+	require.Nil(t, cases["Books can be loaded"].SkipMessage)
+	require.NotNil(t, cases["Books can be saved"].Failure)
+	require.Contains(t, cases["Books can be saved"].Failure.Contents, "Expected <bool>: false to be true")
+	require.Contains(t, cases["Books can be saved"].Failure.Contents, "output of Books can be saved")
+	require.NotNil(t, cases["Books when empty has no pages"].SkipMessage)
+	require.Equal(t, "not implemented yet", cases["Books when empty has no pages"].SkipMessage.Message)
+
+	// Specs are found with dry run and split between two instances of provider.
+	content, err := ioutil.ReadFile(logFile)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 3)
+	require.Contains(t, lines[0], "--dry-run")
+	require.Contains(t, lines[0], "--label-filter !slow")
+	for _, line := range lines[1:] {
+		require.NotContains(t, line, "--dry-run")
+		require.Contains(t, line, "--focus ^(?:Books")
+	}
+
+	// Reports are stored in artifacts directories, only their paths are written into output.
+	reports, err := filepath.Glob(path.Join(testConfig.ConfigRoot, "a_provider-*", "*", "ginkgo-report-*.json"))
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	outputs, err := filepath.Glob(path.Join(testConfig.ConfigRoot, "a_provider-*", "*-run.log"))
+	require.NoError(t, err)
+	require.NotEmpty(t, outputs)
+	for _, output := range outputs {
+		content, err := ioutil.ReadFile(output)
+		require.NoError(t, err)
+		require.NotContains(t, string(content), "SpecReports")
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cloudtest/pkg/commands"
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+func TestHTMLReport(t *testing.T) {
+	testConfig := config.NewCloudTestConfig()
+	testConfig.Timeout = 300
+
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "cloud-test-html")
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+	testConfig.ConfigRoot = path.Join(tmpDir, "root")
+	testConfig.Reporting.HTMLReportFile = "reports/report.html"
+	createProvider(testConfig, "a_provider").Instances = 1
+
+	testConfig.Executions = append(testConfig.Executions, &config.Execution{
+		Name:        "simple",
+		Timeout:     15,
+		PackageRoot: "./sample",
+		OnlyRun:     []string{"TestPass", "TestFail"},
+	})
+
+	_, err = commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
+	require.Error(t, err)
+
+	content, err := ioutil.ReadFile(path.Join(testConfig.ConfigRoot, "reports", "report.html"))
+	require.NoError(t, err)
+	report := string(content)
+	require.Contains(t, report, "<summary>simple</summary>")
+	require.Contains(t, report, "<td>TestPass</td>")
+	require.Contains(t, report, "<td>TestFail</td>")
+	require.Contains(t, report, `<td class="failed">failed</td>`)
+	require.Contains(t, report, `<div class="timeline-name">a_provider-1</div>`)
+	require.Contains(t, report, "<td>start</td>")
+	require.Contains(t, report, "<td>destroy</td>")
+	// Links are relative to report location.
+	require.Contains(t, report, `href="../a_provider-1/`)
+	require.NotContains(t, report, "<link ")
+	require.NotContains(t, report, "<script src")
+}
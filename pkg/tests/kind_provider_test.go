@@ -0,0 +1,124 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/execmanager"
+	"github.com/networkservicemesh/cloudtest/pkg/providers"
+	"github.com/networkservicemesh/cloudtest/pkg/providers/kind"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+// fakeKind - a kind replacement logging its arguments, it reports a leaked and a foreign clusters as existing ones.
+const fakeKind = `#!/bin/sh
+echo "$@" >> "${KIND_LOG}"
+if [ "$1 $2" = "get clusters" ]; then
+  echo cloudtest-leaked
+  echo other-cluster
+fi
+while [ $# -gt 0 ]; do
+  if [ "$1" = "--kubeconfig" ]; then
+    echo "kubeconfig" > "$2"
+  fi
+  shift
+done
+`
+
+func newKindProviderConfig(t *testing.T, tmpDir string) *config.ClusterProviderConfig {
+	binary := path.Join(tmpDir, "kind")
+	require.NoError(t, ioutil.WriteFile(binary, []byte(fakeKind), 0700))
+	return &config.ClusterProviderConfig{
+		Name:       "kind",
+		Kind:       "kind",
+		Instances:  1,
+		NodeCount:  1,
+		RetryCount: 1,
+		Env:        []string{"KIND_LOG=" + path.Join(tmpDir, "kind.log")},
+		KindCluster: &config.KindConfig{
+			Binary:     binary,
+			ConfigData: "kind: Cluster\napiVersion: kind.x-k8s.io/v1alpha4\n",
+			Images:     []string{"busybox:1.0"},
+		},
+	}
+}
+
+func TestKindProviderStartDestroy(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "cloud-test-kind")
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	cfg := newKindProviderConfig(t, tmpDir)
+	manager := execmanager.NewExecutionManager(path.Join(tmpDir, "results"))
+	provider := kind.NewKindClusterProvider(path.Join(tmpDir, "kind-root"))
+
+	instance, err := provider.CreateCluster(cfg, &TestValidationFactory{}, manager, providers.InstanceOptions{})
+	require.NoError(t, err)
+
+	_, err = instance.Start(time.Minute)
+	require.NoError(t, err)
+	require.True(t, instance.IsRunning())
+
+	kubeConfig, err := instance.GetClusterConfig()
+	require.NoError(t, err)
+	require.Equal(t, path.Join(instance.GetRoot(), "config"), kubeConfig)
+	require.FileExists(t, kubeConfig)
+
+	require.NoError(t, instance.Destroy(time.Minute))
+	require.False(t, instance.IsRunning())
+
+	log, err := ioutil.ReadFile(path.Join(tmpDir, "kind.log"))
+	require.NoError(t, err)
+	name := regexp.MustCompile(`--name (cloudtest-[0-9a-f]+)`).FindStringSubmatch(string(log))
+	require.Len(t, name, 2)
+	require.Equal(t,
+		"create cluster --config "+path.Join(instance.GetRoot(), "kind.yaml")+" --name "+name[1]+" --kubeconfig "+kubeConfig+"\n"+
+			"load docker-image busybox:1.0 --name "+name[1]+"\n"+
+			"delete cluster --name "+name[1]+"\n",
+		string(log))
+}
+
+func TestKindProviderCleanupLeakedClusters(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "cloud-test-kind")
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	cfg := newKindProviderConfig(t, tmpDir)
+	manager := execmanager.NewExecutionManager(path.Join(tmpDir, "results"))
+	provider := kind.NewKindClusterProvider(path.Join(tmpDir, "kind-root"))
+
+	// Cleanup is disabled by default, since clusters could belong to other cloudtest processes.
+	provider.CleanupClusters(context.Background(), cfg, manager, providers.InstanceOptions{})
+	require.NoFileExists(t, path.Join(tmpDir, "kind.log"))
+
+	cfg.KindCluster.CleanupLeaked = true
+	provider.CleanupClusters(context.Background(), cfg, manager, providers.InstanceOptions{})
+
+	log, err := ioutil.ReadFile(path.Join(tmpDir, "kind.log"))
+	require.NoError(t, err)
+	require.Equal(t, "get clusters\ndelete cluster --name cloudtest-leaked\n", string(log))
+}
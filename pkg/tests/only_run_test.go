@@ -17,6 +17,7 @@
 package tests
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
 	"testing"
@@ -48,7 +49,7 @@ func TestOnlyRun(t *testing.T) {
 
 	testConfig.OnlyRun = []string{"TestPass"}
 
-	report, err := commands.PerformTesting(testConfig, &TestValidationFactory{}, &commands.Arguments{})
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
 	if err != nil {
 		logrus.Errorf("Testing failed: %v", err)
 	}
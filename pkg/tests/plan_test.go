@@ -0,0 +1,94 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cloudtest/pkg/commands"
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+func TestPlanDoesNotStartClusters(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "cloud-test-temp")
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	testConfig := config.NewCloudTestConfig()
+	testConfig.ConfigRoot = tmpDir
+	testConfig.Timeout = 300
+	testConfig.MinSuiteSize = 3
+	createProvider(testConfig, "a_provider")
+	createProvider(testConfig, "b_provider")
+	testConfig.Providers[0].Scripts["start"] = "false"
+	testConfig.Executions = append(testConfig.Executions, &config.Execution{
+		Name:        "simple",
+		Timeout:     15,
+		PackageRoot: "./sample",
+	}, &config.Execution{
+		Name:            "suites",
+		Timeout:         5,
+		PackageRoot:     "./sample/suites",
+		ClusterSelector: []string{"a_provider"},
+	}, &config.Execution{
+		Name:            "missing",
+		Timeout:         5,
+		PackageRoot:     "./sample",
+		ClusterSelector: []string{"c_provider"},
+		OnlyRun:         []string{"TestPass"},
+	})
+
+	plan, err := commands.PerformPlan(testConfig, &TestValidationFactory{})
+	require.NoError(t, err)
+
+	require.Len(t, plan.Clusters, 2)
+	require.Equal(t, 2, plan.Clusters[0].Instances)
+
+	suiteTasks := 0
+	for _, task := range plan.Tasks {
+		if task.Suite != "" {
+			suiteTasks++
+			require.Equal(t, []string{"a_provider"}, task.Clusters)
+			require.Equal(t, "10s", task.Timeout)
+			require.NotEmpty(t, task.SuiteTests)
+		}
+	}
+	require.Equal(t, 3*2+suiteTasks, len(plan.Tasks))
+	require.Greater(t, suiteTasks, 0)
+
+	require.Len(t, plan.Skipped, 1)
+	require.Equal(t, "missing", plan.Skipped[0].Execution)
+	require.Equal(t, "TestPass", plan.Skipped[0].Name)
+
+	buffer := &bytes.Buffer{}
+	require.NoError(t, plan.Write(buffer, "json"))
+	decoded := &commands.ExecutionPlan{}
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), decoded))
+	require.Equal(t, plan, decoded)
+
+	// Config root should stay untouched since no cluster is started.
+	files, err := ioutil.ReadDir(tmpDir)
+	require.NoError(t, err)
+	require.Empty(t, files)
+}
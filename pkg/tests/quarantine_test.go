@@ -0,0 +1,166 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cloudtest/pkg/commands"
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/reporting"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+const quarantineFile = `- pattern: ^TestPass$
+  reason: flaky on kind
+  owner: networking
+`
+
+func TestQuarantine(t *testing.T) {
+	logKeeper := utils.NewLogKeeper()
+	defer logKeeper.Stop()
+
+	testConfig := config.NewCloudTestConfig()
+	testConfig.Timeout = 300
+	testConfig.FailedTestsLimit = 1
+
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "cloud-test-quarantine")
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+	testConfig.ConfigRoot = path.Join(tmpDir, "root")
+	createProvider(testConfig, "a_provider").Instances = 1
+
+	fileName := path.Join(tmpDir, "quarantine.yaml")
+	require.NoError(t, ioutil.WriteFile(fileName, []byte(quarantineFile), os.ModePerm))
+	testConfig.Quarantine = config.QuarantineConfig{
+		File: fileName,
+		Tests: []*config.QuarantineEntry{
+			{Pattern: "^TestFail$", Reason: "known bug", Owner: "core", Expires: "2100-01-01"},
+			{Pattern: "^TestTimeout$", Reason: "too slow", Expires: "2000-01-01"},
+		},
+	}
+	testConfig.Executions = append(testConfig.Executions, &config.Execution{
+		Name:        "simple",
+		Timeout:     15,
+		PackageRoot: "./sample",
+		OnlyRun:     []string{"TestPass", "TestFail"},
+	})
+
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
+	require.NoError(t, err)
+
+	cases := map[string]*reporting.TestCase{}
+	collectTestCases(report.Suites[0], cases)
+	require.Nil(t, cases["TestFail"].Failure)
+	require.NotNil(t, cases["TestFail"].SkipMessage)
+	require.Equal(t, "Quarantined: known bug (pattern: ^TestFail$, owner: core, expires: 2100-01-01)",
+		cases["TestFail"].SkipMessage.Message)
+	require.Nil(t, cases["TestPass"].SkipMessage)
+
+	require.Equal(t, 0, logKeeper.MessageCount("Allowed limit for failed tests is reached"))
+	require.Equal(t, 1, logKeeper.MessageCount("Quarantine of ^TestTimeout$ has expired on 2000-01-01"))
+	require.Equal(t, 1, logKeeper.MessageCount("Quarantined test TestPass on a_provider passed all 1 execution(s)"))
+	require.Equal(t, 0, logKeeper.MessageCount("Quarantined test TestFail"))
+}
+
+func TestQuarantineSuiteTests(t *testing.T) {
+	logKeeper := utils.NewLogKeeper()
+	defer logKeeper.Stop()
+
+	testConfig := config.NewCloudTestConfig()
+	testConfig.Timeout = 300
+	testConfig.FailedTestsLimit = 1
+
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "cloud-test-quarantine")
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+	testConfig.ConfigRoot = path.Join(tmpDir, "root")
+	createProvider(testConfig, "a_provider").Instances = 1
+
+	testConfig.Quarantine.Tests = []*config.QuarantineEntry{
+		{Pattern: "^TestFail$", Execution: "suites"},
+	}
+	testConfig.Executions = append(testConfig.Executions, &config.Execution{
+		Name:        "suites",
+		Timeout:     15,
+		PackageRoot: "./sample/suites",
+		Source: config.ExecutionSource{
+			Tags: []string{"quarantine"},
+		},
+	}, &config.Execution{
+		Name:    "after",
+		Timeout: 15,
+		Kind:    "shell",
+		Run:     "sleep 1",
+	})
+
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
+	require.NoError(t, err)
+
+	cases := map[string]*reporting.TestCase{}
+	collectTestCases(report.Suites[0], cases)
+	require.Nil(t, cases["TestFail"].Failure)
+	require.NotNil(t, cases["TestFail"].SkipMessage)
+	require.Equal(t, 0, logKeeper.MessageCount("Allowed limit for failed tests is reached"))
+}
+
+func TestQuarantineExternalTests(t *testing.T) {
+	logKeeper := utils.NewLogKeeper()
+	defer logKeeper.Stop()
+
+	testConfig := config.NewCloudTestConfig()
+	testConfig.Timeout = 300
+	testConfig.FailedTestsLimit = 1
+
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "cloud-test-quarantine")
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+	testConfig.ConfigRoot = path.Join(tmpDir, "root")
+	createProvider(testConfig, "a_provider").Instances = 1
+
+	reportFile := path.Join(tmpDir, "report")
+	require.NoError(t, ioutil.WriteFile(reportFile, []byte(pytestReport), os.ModePerm))
+	testConfig.Quarantine.Tests = []*config.QuarantineEntry{
+		{Pattern: "^test_fail$", Reason: "known bug"},
+	}
+	// Framework exits with error, since test_fail is failed.
+	testConfig.Executions = append(testConfig.Executions, &config.Execution{
+		Name:    "pytest",
+		Timeout: 15,
+		Kind:    "external",
+		Run:     "cp ${REPORT} ${ARTIFACTS_DIR}/report.xml\nfalse",
+		Env:     []string{"REPORT=" + reportFile},
+	})
+
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
+	require.NoError(t, err)
+
+	cases := map[string]*reporting.TestCase{}
+	collectTestCases(report.Suites[0], cases)
+	require.Len(t, cases, 3)
+	require.NotContains(t, cases, "pytest")
+	require.Nil(t, cases["test_fail"].Failure)
+	require.NotNil(t, cases["test_fail"].SkipMessage)
+	require.Equal(t, 0, report.Suites[0].Failures)
+	require.Equal(t, 0, logKeeper.MessageCount("Allowed limit for failed tests is reached"))
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"bufio"
+	"context"
+	"io/ioutil"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cloudtest/pkg/commands"
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/execmanager"
+	"github.com/networkservicemesh/cloudtest/pkg/model"
+	"github.com/networkservicemesh/cloudtest/pkg/providers"
+	"github.com/networkservicemesh/cloudtest/pkg/providers/shell"
+	"github.com/networkservicemesh/cloudtest/pkg/runners"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+var (
+	customProviders int32
+	cancelTesting   context.CancelFunc
+)
+
+type customRunner struct {
+	test *model.TestEntry
+}
+
+func (r *customRunner) Run(timeoutCtx context.Context, env []string, writer *bufio.Writer) error {
+	_, _ = writer.WriteString("custom runner: " + r.test.Name + "\n")
+	_ = writer.Flush()
+	switch r.test.Name {
+	case "CustomFail":
+		return errors.New("custom test failed")
+	case "CustomCancel":
+		cancelTesting()
+		<-timeoutCtx.Done()
+		return timeoutCtx.Err()
+	}
+	return nil
+}
+
+func (r *customRunner) GetCmdLine() string {
+	return "custom " + r.test.Name
+}
+
+func init() {
+	providers.Register("custom", func(root string) providers.ClusterProvider {
+		atomic.AddInt32(&customProviders, 1)
+		return shell.NewShellClusterProvider(root)
+	})
+	runners.Register("custom", runners.Runner{
+		FindTests: func(execution *config.Execution, _ execmanager.ExecutionManager) ([]*model.TestEntry, error) {
+			var result []*model.TestEntry
+			for _, name := range []string{"CustomPass", "CustomFail", "CustomCancel"} {
+				result = append(result, &model.TestEntry{Name: name})
+			}
+			return result, nil
+		},
+		NewRunner: func(_ string, test *model.TestEntry, _ time.Duration) runners.TestRunner {
+			return &customRunner{test: test}
+		},
+	})
+}
+
+func newCustomConfig(t *testing.T, tmpDir string) *config.CloudTestConfig {
+	testConfig := config.NewCloudTestConfig()
+	testConfig.Timeout = 300
+	testConfig.ConfigRoot = tmpDir
+	createProvider(testConfig, "a_provider").Kind = "custom"
+	testConfig.Executions = append(testConfig.Executions, &config.Execution{
+		Name:    "custom",
+		Kind:    "custom",
+		Timeout: 15,
+	})
+	return testConfig
+}
+
+func TestRegisteredProviderAndRunner(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "cloud-test-registry")
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	created := atomic.LoadInt32(&customProviders)
+	report, err := commands.PerformTesting(context.Background(), newCustomConfig(t, tmpDir), &TestValidationFactory{},
+		commands.WithOnlyRun("CustomPass", "CustomFail"))
+	require.Error(t, err)
+	require.Equal(t, "there is failed tests 1", err.Error())
+	require.Equal(t, created+1, atomic.LoadInt32(&customProviders))
+
+	require.NotNil(t, report)
+	rootSuite := report.Suites[0]
+	require.Equal(t, 2, rootSuite.Tests)
+	require.Equal(t, 1, rootSuite.Failures)
+}
+
+func TestPerformTestingCanceled(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "cloud-test-registry")
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+
+	var ctx context.Context
+	ctx, cancelTesting = context.WithCancel(context.Background())
+	defer cancelTesting()
+
+	_, err = commands.PerformTesting(ctx, newCustomConfig(t, tmpDir), &TestValidationFactory{},
+		commands.WithOnlyRun("CustomCancel"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "testing is canceled")
+}
+
+func TestRegisterDuplicateKind(t *testing.T) {
+	require.Panics(t, func() {
+		providers.Register("shell", shell.NewShellClusterProvider)
+	})
+	require.Panics(t, func() {
+		runners.Register("gotest", runners.Runner{
+			NewRunner: func(string, *model.TestEntry, time.Duration) runners.TestRunner { return nil },
+		})
+	})
+}
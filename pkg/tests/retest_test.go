@@ -18,6 +18,7 @@
 package tests
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
 	"testing"
@@ -59,7 +60,7 @@ func TestRestartRequest(t *testing.T) {
 
 	testConfig.Reporting.JUnitReportFile = JunitReport
 
-	report, err := commands.PerformTesting(testConfig, &TestValidationFactory{}, &commands.Arguments{})
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
 	logrus.Info(err.Error())
 	require.Contains(t, err.Error(), "there is failed tests 1")
 
@@ -113,7 +114,7 @@ func TestRestartRetestDestroyCluster(t *testing.T) {
 
 	testConfig.Reporting.JUnitReportFile = JunitReport
 
-	report, err := commands.PerformTesting(testConfig, &TestValidationFactory{}, &commands.Arguments{})
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
 	require.Contains(t, err.Error(), "there is failed tests 1")
 
 	require.NotNil(t, report)
@@ -174,7 +175,7 @@ func TestRestartRequestRestartCluster(t *testing.T) {
 
 	testConfig.Reporting.JUnitReportFile = JunitReport
 
-	report, err := commands.PerformTesting(testConfig, &TestValidationFactory{}, &commands.Arguments{})
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
 	require.Contains(t, err.Error(), "there is failed tests 1")
 
 	require.NotNil(t, report)
@@ -190,7 +191,7 @@ func TestRestartRequestRestartCluster(t *testing.T) {
 		"Reached a limit of re-tests per cluster instance",
 		"Destroying cluster",
 		"Starting cluster ",
-		"Test TestRequestRestart retry count 3 exceed: err: failed to run go test",
+		"Test TestRequestRestart retry count 3 exceed: err: failed to run \"",
 	})
 	require.Equal(t, 3, logKeeper.MessageCount("Re schedule task TestRequestRestart reason: rerun-request"))
 }
@@ -225,7 +226,7 @@ func TestRestartRequestSkip(t *testing.T) {
 
 	testConfig.Reporting.JUnitReportFile = JunitReport
 
-	report, err := commands.PerformTesting(testConfig, &TestValidationFactory{}, &commands.Arguments{})
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
 	require.NoError(t, err)
 
 	require.NotNil(t, report)
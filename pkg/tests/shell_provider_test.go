@@ -17,6 +17,7 @@
 package tests
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
 	"strings"
@@ -64,7 +65,7 @@ func TestShellProvider(t *testing.T) {
 
 	testConfig.Reporting.JUnitReportFile = JunitReport
 
-	report, err := commands.PerformTesting(testConfig, &TestValidationFactory{}, &commands.Arguments{})
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
 	require.Equal(t, "there is failed tests 4", err.Error())
 
 	require.NotNil(t, report)
@@ -123,7 +124,7 @@ func TestInvalidProvider(t *testing.T) {
 		PackageRoot: "./sample",
 	})
 
-	report, err := commands.PerformTesting(testConfig, &TestValidationFactory{}, &commands.Arguments{})
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
 	logrus.Error(err.Error())
 	require.Equal(t, "Failed to create cluster instance. Error invalid start script", err.Error())
 
@@ -152,7 +153,7 @@ func TestRequireEnvVars(t *testing.T) {
 		PackageRoot: "./sample",
 	})
 
-	report, err := commands.PerformTesting(testConfig, &TestValidationFactory{}, &commands.Arguments{})
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
 	logrus.Error(err.Error())
 	require.Equal(t, err.Error(), "Failed to create cluster instance. Error environment variable are not specified  Required variables: [KUBECONFIG QWE]")
 
@@ -197,7 +198,7 @@ func TestRequireEnvVars_DEPS(t *testing.T) {
 		PackageRoot: "./sample",
 	})
 
-	report, err := commands.PerformTesting(testConfig, &TestValidationFactory{}, &commands.Arguments{})
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
 	require.Contains(t, err.Error(), "there is failed tests 2")
 
 	require.NotNil(t, report)
@@ -246,7 +247,7 @@ func TestShellProviderShellTest(t *testing.T) {
 
 	testConfig.Reporting.JUnitReportFile = JunitReport
 
-	report, err := commands.PerformTesting(testConfig, &TestValidationFactory{}, &commands.Arguments{})
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
 	require.Equal(t, "there is failed tests 4", err.Error())
 
 	require.NotNil(t, report)
@@ -310,7 +311,7 @@ func TestUnusedClusterShutdownByMonitor(t *testing.T) {
 
 	testConfig.Reporting.JUnitReportFile = JunitReport
 
-	report, err := commands.PerformTesting(testConfig, &TestValidationFactory{}, &commands.Arguments{})
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
 	require.Contains(t, err.Error(), "there is failed tests 2")
 
 	require.NotNil(t, report)
@@ -385,7 +386,7 @@ func TestMultiClusterTest(t *testing.T) {
 
 	testConfig.Reporting.JUnitReportFile = JunitReport
 
-	report, err := commands.PerformTesting(testConfig, &TestValidationFactory{}, &commands.Arguments{})
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
 	require.Equal(t, "there is failed tests 3", err.Error())
 
 	require.NotNil(t, report)
@@ -422,7 +423,7 @@ func TestGlobalTimeout(t *testing.T) {
 
 	testConfig.Reporting.JUnitReportFile = JunitReport
 
-	report, err := commands.PerformTesting(testConfig, &TestValidationFactory{}, &commands.Arguments{})
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
 	require.Equal(t, "global timeout elapsed: 3 seconds", err.Error())
 
 	require.NotNil(t, report)
@@ -0,0 +1,122 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cloudtest/pkg/commands"
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+const staticKubeConfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: first
+  cluster:
+    server: https://first:6443
+- name: second
+  cluster:
+    server: https://second:6443
+contexts:
+- name: first
+  context:
+    cluster: first
+    user: admin
+- name: second
+  context:
+    cluster: second
+    user: admin
+current-context: first
+users:
+- name: admin
+  user:
+    token: secret
+`
+
+func TestStaticProvider(t *testing.T) {
+	testConfig := config.NewCloudTestConfig()
+	testConfig.Timeout = 300
+
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "cloud-test-static")
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+	testConfig.ConfigRoot = path.Join(tmpDir, "root")
+
+	kubeConfig := path.Join(tmpDir, "kubeconfig")
+	require.NoError(t, ioutil.WriteFile(kubeConfig, []byte(staticKubeConfig), 0600))
+	testsLog := path.Join(tmpDir, "tests.log")
+	resetLog := path.Join(tmpDir, "reset.log")
+
+	testConfig.Providers = append(testConfig.Providers, &config.ClusterProviderConfig{
+		Name:      "bare-metal",
+		Kind:      "static",
+		Instances: 1,
+		NodeCount: 1,
+		Enabled:   true,
+		Timeout:   100,
+		Static: &config.StaticConfig{
+			Clusters: []*config.StaticClusterConfig{
+				{KubeConfig: kubeConfig, Context: "second"},
+			},
+		},
+		Scripts: map[string]string{
+			"reset": fmt.Sprintf("sh -c \"echo reset >> %v\"", resetLog),
+		},
+	})
+	for _, name := range []string{"TestPass1", "TestPass2"} {
+		testConfig.Executions = append(testConfig.Executions, &config.Execution{
+			Name:        name,
+			Timeout:     15,
+			PackageRoot: "./sample",
+			Source: config.ExecutionSource{
+				Tags: []string{"passed"},
+			},
+			OnlyRun: []string{name},
+			Before:  fmt.Sprintf("sh -c \"grep -e server -e current-context ${KUBECONFIG} >> %v\"", testsLog),
+		})
+	}
+
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
+	require.NoError(t, err)
+	require.Len(t, report.Suites[0].Suites, 2)
+	require.Equal(t, 0, report.Suites[0].Failures)
+
+	tests, err := ioutil.ReadFile(testsLog)
+	require.NoError(t, err)
+	require.Equal(t,
+		"    server: https://second:6443\ncurrent-context: second\n"+
+			"    server: https://second:6443\ncurrent-context: second\n",
+		string(tests))
+
+	resets, err := ioutil.ReadFile(resetLog)
+	require.NoError(t, err)
+	require.Equal(t, "reset\nreset\n", string(resets))
+
+	// Existing kubeconfig is never changed or deleted.
+	content, err := ioutil.ReadFile(kubeConfig)
+	require.NoError(t, err)
+	require.Equal(t, staticKubeConfig, string(content))
+}
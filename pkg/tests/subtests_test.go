@@ -0,0 +1,99 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cloudtest/pkg/commands"
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/reporting"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+func TestGoSubtestsReport(t *testing.T) {
+	testConfig := config.NewCloudTestConfig()
+	testConfig.Timeout = 300
+
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "cloud-test-subtests")
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+	testConfig.ConfigRoot = tmpDir
+	testConfig.Reporting.JSONReportFile = "summary.json"
+	createProvider(testConfig, "a_provider").Instances = 1
+
+	testConfig.Executions = append(testConfig.Executions, &config.Execution{
+		Name:    "subtests",
+		Timeout: 15,
+		Source: config.ExecutionSource{
+			Tags: []string{"subtests"},
+		},
+		PackageRoot: "./sample",
+		OnFail:      "echo >>>Running on fail script<<<",
+	})
+
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
+	require.Error(t, err)
+
+	rootSuite := report.Suites[0]
+	require.Equal(t, 4, rootSuite.Tests)
+	require.Equal(t, 2, rootSuite.Failures)
+
+	cases := map[string]*reporting.TestCase{}
+	collectTestCases(rootSuite, cases)
+	require.Len(t, cases, 4)
+
+	// Test itself gets output of go test and scripts not related to subtests.
+	require.NotNil(t, cases["TestTable"].Failure)
+	require.Contains(t, cases["TestTable"].Failure.Contents, ">>>Running on fail script<<<")
+
+	require.Nil(t, cases["TestTable/first"].Failure)
+	require.Nil(t, cases["TestTable/first"].SkipMessage)
+
+	require.NotNil(t, cases["TestTable/second"].Failure)
+	require.Contains(t, cases["TestTable/second"].Failure.Contents, "Case is failed")
+	require.NotContains(t, cases["TestTable/second"].Failure.Contents, "Running first case")
+
+	require.NotNil(t, cases["TestTable/third"].SkipMessage)
+	require.Contains(t, cases["TestTable/third"].SkipMessage.Message, "Case is not supported")
+
+	// Subtests are listed in summary reports as test cases of test.
+	content, err := ioutil.ReadFile(path.Join(testConfig.ConfigRoot, "summary.json"))
+	require.NoError(t, err)
+	summary := &reporting.Summary{}
+	require.NoError(t, json.Unmarshal(content, summary))
+	// Configuration is not embedded by default, since environment could contain secrets.
+	require.Nil(t, summary.Config)
+	test := summary.Executions[0].Groups[0].Tests[0]
+	require.Equal(t, "TestTable", test.Name)
+	statuses := map[string]string{}
+	for _, testCase := range test.Cases {
+		statuses[testCase.Name] = testCase.Status
+	}
+	require.Equal(t, map[string]string{
+		"TestTable/first":  "success",
+		"TestTable/second": "failed",
+		"TestTable/third":  "skipped",
+	}, statuses)
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cloudtest/pkg/commands"
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/reporting"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+func TestSummaryReports(t *testing.T) {
+	testConfig := config.NewCloudTestConfig()
+	testConfig.Timeout = 300
+
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "cloud-test-json")
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+	testConfig.ConfigRoot = path.Join(tmpDir, "root")
+	testConfig.Reporting.JSONReportFile = "summary.json"
+	testConfig.Reporting.JSONConfig = true
+	testConfig.Reporting.MarkdownReportFile = "summary.md"
+	createProvider(testConfig, "a_provider").Instances = 1
+	failedProvider := createProvider(testConfig, "b_provider")
+	failedProvider.Instances = 1
+	failedProvider.RetryCount = 0
+	failedProvider.Scripts["start"] = "echo starting\nexit 2"
+
+	testConfig.Executions = append(testConfig.Executions, &config.Execution{
+		Name:            "simple",
+		Timeout:         15,
+		PackageRoot:     "./sample",
+		ClusterSelector: []string{"a_provider"},
+		Source: config.ExecutionSource{
+			Tests: []string{"TestPass", "TestFail"},
+		},
+	}, &config.Execution{
+		Name:            "no-clusters",
+		Timeout:         15,
+		PackageRoot:     "./sample",
+		ClusterSelector: []string{"b_provider"},
+		Source: config.ExecutionSource{
+			Tests: []string{"TestPass"},
+		},
+	})
+
+	_, err = commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
+	require.Error(t, err)
+
+	content, err := ioutil.ReadFile(path.Join(testConfig.ConfigRoot, "summary.json"))
+	require.NoError(t, err)
+	summary := &reporting.Summary{}
+	require.NoError(t, json.Unmarshal(content, summary))
+
+	require.Equal(t, reporting.SummaryVersion, summary.Version)
+	require.Equal(t, map[string]int{
+		"success":             1,
+		"failed":              1,
+		"skipped-no-clusters": 1,
+	}, summary.Statuses)
+
+	require.Len(t, summary.Executions, 2)
+	require.Equal(t, "simple", summary.Executions[0].Name)
+	require.Equal(t, "a_provider", summary.Executions[0].Groups[0].Name)
+	tests := map[string]*reporting.TestSummary{}
+	for _, test := range summary.Executions[0].Groups[0].Tests {
+		tests[test.Name] = test
+	}
+	require.Equal(t, "success", tests["TestPass"].Status)
+	require.Len(t, tests["TestFail"].Executions, 1)
+	require.Equal(t, "failed", tests["TestFail"].Executions[0].Status)
+	require.Equal(t, []string{"a_provider-1"}, tests["TestFail"].Executions[0].Instances)
+	require.FileExists(t, tests["TestFail"].Executions[0].OutputFile)
+	require.Equal(t, "skipped-no-clusters", summary.Executions[1].Groups[0].Tests[0].Status)
+
+	require.Len(t, summary.Clusters, 2)
+	failed := summary.Clusters[1].Instances[0]
+	require.Equal(t, "b_provider-1", failed.ID)
+	require.Equal(t, "start", failed.Operations[0].Name)
+	require.Equal(t, "failed", failed.Operations[0].Status)
+	require.Equal(t, 1, failed.Operations[0].Attempt)
+	require.NotEmpty(t, failed.Operations[0].Error)
+
+	cfg, ok := summary.Config.(map[string]interface{})
+	require.True(t, ok)
+	require.Equal(t, "summary.json", cfg["reporting"].(map[string]interface{})["json-report"])
+
+	markdown, err := ioutil.ReadFile(path.Join(testConfig.ConfigRoot, "summary.md"))
+	require.NoError(t, err)
+	require.Contains(t, string(markdown), "| simple | a_provider | 1 | 1 | 0 | 0 | 0 | 2 |")
+	require.Contains(t, string(markdown), "| no-clusters | b_provider | 0 | 0 | 0 | 1 | 0 | 1 |")
+	require.Contains(t, string(markdown), "## Cluster failures")
+	require.Contains(t, string(markdown), "### simple / a_provider / TestFail")
+}
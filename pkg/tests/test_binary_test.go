@@ -0,0 +1,116 @@
+// Copyright (c) 2021 Doc.ai and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cloudtest/pkg/commands"
+	"github.com/networkservicemesh/cloudtest/pkg/config"
+	"github.com/networkservicemesh/cloudtest/pkg/reporting"
+	"github.com/networkservicemesh/cloudtest/pkg/utils"
+)
+
+func TestCompiledTestBinary(t *testing.T) {
+	testConfig := config.NewCloudTestConfig()
+	testConfig.Timeout = 300
+
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "cloud-test-binary")
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+	testConfig.ConfigRoot = tmpDir
+	createProvider(testConfig, "a_provider").Instances = 1
+
+	testConfig.Executions = append(testConfig.Executions, &config.Execution{
+		Name:        "simple",
+		Timeout:     15,
+		PackageRoot: "./sample",
+		OnlyRun:     []string{"TestPass", "TestFail"},
+	})
+
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
+	require.Error(t, err)
+	require.Equal(t, 2, report.Suites[0].Tests)
+	require.Equal(t, 1, report.Suites[0].Failures)
+
+	// Package is compiled once during discovery.
+	binary := path.Join(tmpDir, "gotest", "bin", "simple.test")
+	require.True(t, utils.FileExists(binary))
+
+	cases := map[string]*reporting.TestCase{}
+	collectTestCases(report.Suites[0], cases)
+	require.NotNil(t, cases["TestFail"].Failure)
+	require.Contains(t, cases["TestFail"].Failure.Contents, "\""+binary+"\" -test.timeout")
+
+	// Tests are listed from binary, not compiled again with go test --list.
+	logs, err := filepath.Glob(path.Join(tmpDir, "gotest", "*find-tests*"))
+	require.NoError(t, err)
+	require.NotEmpty(t, logs)
+	for _, log := range logs {
+		content, err := ioutil.ReadFile(log)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(string(content), binary+" -test.list"), string(content))
+	}
+}
+
+func TestPrebuiltTestBinary(t *testing.T) {
+	testConfig := config.NewCloudTestConfig()
+	testConfig.Timeout = 300
+
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "cloud-test-binary")
+	require.NoError(t, err)
+	defer utils.ClearFolder(tmpDir, false)
+	testConfig.ConfigRoot = path.Join(tmpDir, "root")
+	createProvider(testConfig, "a_provider").Instances = 1
+
+	binary := path.Join(tmpDir, "sample.test")
+	out, err := exec.Command("go", "test", "-c", "-tags", "subtests", "-o", binary, "./sample").CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	testConfig.Executions = append(testConfig.Executions, &config.Execution{
+		Name:    "prebuilt",
+		Timeout: 15,
+		Source: config.ExecutionSource{
+			Tags: []string{"subtests"},
+		},
+		PackageRoot: "./sample",
+		TestBinary:  binary,
+	})
+
+	report, err := commands.PerformTesting(context.Background(), testConfig, &TestValidationFactory{})
+	require.Error(t, err)
+	require.False(t, utils.FileExists(path.Join(testConfig.ConfigRoot, "gotest", "bin", "prebuilt.test")))
+
+	// Only tests of files built with tags are taken from prebuilt binary.
+	cases := map[string]*reporting.TestCase{}
+	collectTestCases(report.Suites[0], cases)
+	require.Len(t, cases, 4)
+	require.NotNil(t, cases["TestTable"].Failure)
+	require.Contains(t, cases["TestTable"].Failure.Contents, "\""+binary+"\" -test.timeout")
+	require.Nil(t, cases["TestTable/first"].Failure)
+	require.NotNil(t, cases["TestTable/second"].Failure)
+	require.NotNil(t, cases["TestTable/third"].SkipMessage)
+}
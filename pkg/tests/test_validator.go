@@ -18,12 +18,16 @@ package tests
 
 import (
 	"context"
+	"sync"
 
 	"github.com/networkservicemesh/cloudtest/pkg/config"
 	"github.com/networkservicemesh/cloudtest/pkg/k8s"
 )
 
 type TestValidationFactory struct {
+	sync.Mutex
+	CreatedNamespaces []string
+	DeletedNamespaces []string
 }
 
 type testValidator struct {
@@ -46,3 +50,27 @@ func (*TestValidationFactory) CreateValidator(config *config.ClusterProviderConf
 		location: location,
 	}, nil
 }
+
+type testNamespaceManager struct {
+	factory *TestValidationFactory
+}
+
+func (m *testNamespaceManager) CreateNamespace(_ context.Context, name string) error {
+	m.factory.Lock()
+	defer m.factory.Unlock()
+	m.factory.CreatedNamespaces = append(m.factory.CreatedNamespaces, name)
+	return nil
+}
+
+func (m *testNamespaceManager) DeleteNamespace(_ context.Context, name string) error {
+	m.factory.Lock()
+	defer m.factory.Unlock()
+	m.factory.DeletedNamespaces = append(m.factory.DeletedNamespaces, name)
+	return nil
+}
+
+func (f *TestValidationFactory) CreateNamespaceManager(location string) (k8s.NamespaceManager, error) {
+	return &testNamespaceManager{
+		factory: f,
+	}, nil
+}
@@ -201,9 +201,11 @@ func RunCommand(context context.Context, cmd, dir string, logger func(str string
 
 	builder := strings.Builder{}
 	var wg sync.WaitGroup
+	// Stdout and stderr are written into same writer, lines should not be interleaved.
+	var writerMutex sync.Mutex
 	wg.Add(2)
-	processOutput(proc.Stdout, writer, logger, "StdOut", &builder, returnStdout, &wg)
-	processOutput(proc.Stderr, writer, logger, "StdErr", nil, false, &wg)
+	processOutput(proc.Stdout, writer, &writerMutex, logger, "StdOut", &builder, returnStdout, &wg)
+	processOutput(proc.Stderr, writer, &writerMutex, logger, "StdErr", nil, false, &wg)
 	wg.Wait()
 	code := proc.ExitCode()
 	if code != 0 {
@@ -215,7 +217,7 @@ func RunCommand(context context.Context, cmd, dir string, logger func(str string
 	return "", nil
 }
 
-func processOutput(stream io.Reader, writer *bufio.Writer, logger func(str string), pattern string, builder io.StringWriter, returnStdout bool, wg *sync.WaitGroup) {
+func processOutput(stream io.Reader, writer *bufio.Writer, writerMutex *sync.Mutex, logger func(str string), pattern string, builder io.StringWriter, returnStdout bool, wg *sync.WaitGroup) {
 	go func() {
 		defer wg.Done()
 		reader := bufio.NewReader(stream)
@@ -224,8 +226,10 @@ func processOutput(stream io.Reader, writer *bufio.Writer, logger func(str strin
 			if err != nil {
 				break
 			}
+			writerMutex.Lock()
 			_, _ = writer.WriteString(s)
 			_ = writer.Flush()
+			writerMutex.Unlock()
 			if len(strings.TrimSpace(s)) > 0 {
 				logger(fmt.Sprintf("%s => %v", pattern, s))
 			}